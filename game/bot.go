@@ -0,0 +1,272 @@
+package game
+
+import (
+	"fmt"
+	"time"
+)
+
+// a hand needs at least this much strength before the bot will call trump
+const orderUpStrength = 7
+
+// a hand needs at least this much strength before the bot will go alone
+const goAloneStrength = 11
+
+// NewBotController creates a computer opponent using the named strategy
+func NewBotController(strategy string) (PlayerController, error) {
+	switch strategy {
+	case "rule":
+		return NewRuleBotController(), nil
+	case "montecarlo":
+		return NewMonteCarloController(0, time.Second, time.Now().UnixNano()), nil
+	}
+	return nil, fmt.Errorf("unknown bot strategy %q", strategy)
+}
+
+// RuleBotController is a computer opponent that makes its decisions with the
+// usual rules of thumb a euchre player learns at the kitchen table. It only ever
+// plays cards returned by GetPlayableCards so it can't make an illegal play.
+type RuleBotController struct{}
+
+func NewRuleBotController() *RuleBotController {
+	return &RuleBotController{}
+}
+
+// OrderUp orders the turned card up when the hand is strong in its suite. The
+// dealer counts the turned card as part of their hand, and a turned card going to
+// the other team makes the bot a little more cautious.
+func (bot *RuleBotController) OrderUp(player *Player, game *Game) bool {
+	turnedCard := game.TurnedCard
+	trump := turnedCard.suite
+
+	strength := handStrength(player.hand, trump)
+	if game.DealerIndex == player.index {
+		strength = pickupStrength(player.hand, turnedCard, trump)
+	} else if game.partnerOf(player.index) == game.DealerIndex {
+		strength += 1
+	} else {
+		strength -= 1
+	}
+
+	return strength >= orderUpStrength
+}
+
+// PickSuite picks the strongest suite in the hand that wasn't turned down
+func (bot *RuleBotController) PickSuite(player *Player, game *Game, mustPick bool) Suite {
+	bestSuite := NONE
+	bestStrength := 0
+	for _, suite := range []Suite{DIAMOND, CLUB, HEART, SPADE} {
+		if suite == game.TurnedCard.suite {
+			continue
+		}
+		strength := handStrength(player.hand, suite)
+		if bestSuite == NONE || strength > bestStrength {
+			bestSuite = suite
+			bestStrength = strength
+		}
+	}
+
+	if mustPick || bestStrength >= orderUpStrength {
+		return bestSuite
+	}
+	return NONE
+}
+
+// GoAlone plays without a partner when the hand is strong enough to take the
+// tricks by itself
+func (bot *RuleBotController) GoAlone(player *Player, game *Game) bool {
+	strength := handStrength(player.hand, game.Trump)
+
+	// the dealer is about to pick up the turned card
+	if game.TurnedCard != nil && game.DealerIndex == player.index {
+		strength = pickupStrength(player.hand, game.TurnedCard, game.Trump)
+	}
+
+	return strength >= goAloneStrength
+}
+
+// Discard throws away the weakest card in the hand
+func (bot *RuleBotController) Discard(player *Player, game *Game) *Card {
+	if game.Rules.DealerMustPickUp {
+		hand := make([]*Card, 0)
+		for _, c := range player.hand {
+			if c != game.TurnedCard {
+				hand = append(hand, c)
+			}
+		}
+		return chooseBurnCard(hand, game.Trump)
+	}
+	return chooseBurnCard(player.hand, game.Trump)
+}
+
+// PlayCard leads trump when the bot's team called it, plays low when its
+// partner is already winning the trick and otherwise tries to win as cheaply as
+// possible.
+func (bot *RuleBotController) PlayCard(player *Player, game *Game) *Card {
+	leadCard := game.Trick.LeadCard()
+	playableCards := GetPlayableCards(player.hand, game.Trump, leadCard)
+
+	if leadCard == nil {
+		isMaker := game.MakerTeam().HasMember(player.index)
+		return chooseLeadCard(playableCards, game.Trump, isMaker)
+	}
+	return chooseFollowCard(playableCards, &game.Trick, game.partnerOf(player.index), game.Trump)
+}
+
+// chooseLeadCard picks the card to start a trick with
+func chooseLeadCard(playableCards []*Card, trump Suite, isMaker bool) *Card {
+	// pull trump out of the defenders hands if we called it
+	if isMaker {
+		highestTrump := highestCard(trumpCards(playableCards, trump), trump, trump)
+		if highestTrump != nil {
+			return highestTrump
+		}
+	}
+
+	// cash an off-suite ace if we have one
+	for _, c := range playableCards {
+		if c.rank == ACE && !isTrumpCard(c, trump) {
+			return c
+		}
+	}
+
+	// otherwise give up as little as possible
+	offSuiteCards := make([]*Card, 0)
+	for _, c := range playableCards {
+		if !isTrumpCard(c, trump) {
+			offSuiteCards = append(offSuiteCards, c)
+		}
+	}
+	if len(offSuiteCards) > 0 {
+		return lowestCard(offSuiteCards, trump, NONE)
+	}
+	return lowestCard(playableCards, trump, NONE)
+}
+
+// chooseFollowCard picks the card to play when the trick has already been led.
+// partner is the seat of the player's partner, or -1 if they don't have one.
+func chooseFollowCard(playableCards []*Card, trick *Trick, partner int, trump Suite) *Card {
+	lead := EffectiveSuit(trick.Plays[0].Card, trump)
+
+	// find the card that is currently winning the trick and who played it
+	winningPlay := trick.Winner(trump)
+
+	// don't waste a good card if our partner already has the trick
+	if winningPlay.Seat == partner {
+		return lowestCard(playableCards, trump, lead)
+	}
+
+	// win the trick as cheaply as possible
+	winningCards := make([]*Card, 0)
+	for _, c := range playableCards {
+		if c.compare(winningPlay.Card, trump, lead) > 0 {
+			winningCards = append(winningCards, c)
+		}
+	}
+	if len(winningCards) > 0 {
+		return lowestCard(winningCards, trump, lead)
+	}
+
+	// we can't win, so throw away our worst card
+	return lowestCard(playableCards, trump, lead)
+}
+
+// chooseBurnCard picks the card to discard from a hand. Off-suite cards in the
+// shortest suite are thrown first so the hand can trump that suite later. Trump
+// and aces are kept as long as possible.
+func chooseBurnCard(hand []*Card, trump Suite) *Card {
+	suiteCounts := make(map[Suite]int)
+	for _, c := range hand {
+		if !isTrumpCard(c, trump) {
+			suiteCounts[c.suite] += 1
+		}
+	}
+
+	var burnCard *Card = nil
+	for _, c := range hand {
+		if isTrumpCard(c, trump) || c.rank == ACE {
+			continue
+		}
+		if burnCard == nil || suiteCounts[c.suite] < suiteCounts[burnCard.suite] ||
+			(suiteCounts[c.suite] == suiteCounts[burnCard.suite] && c.rank < burnCard.rank) {
+			burnCard = c
+		}
+	}
+
+	if burnCard != nil {
+		return burnCard
+	}
+
+	// the hand is only trump and aces
+	return lowestCard(hand, trump, NONE)
+}
+
+// handStrength scores how well a hand would do if trump was the given suite
+func handStrength(hand []*Card, trump Suite) int {
+	strength := 0
+	for _, c := range hand {
+		strength += cardStrength(c, trump)
+	}
+	return strength
+}
+
+// pickupStrength scores a dealer's hand after they pick up the turned card and
+// discard their weakest card
+func pickupStrength(hand []*Card, turnedCard *Card, trump Suite) int {
+	hand = append(append([]*Card{}, hand...), turnedCard)
+	burnCard := chooseBurnCard(hand, trump)
+	return handStrength(hand, trump) - cardStrength(burnCard, trump)
+}
+
+// cardStrength scores a single card for handStrength. Bauers are worth the most,
+// then the rest of the trump, then off-suite aces.
+func cardStrength(c *Card, trump Suite) int {
+	if c.rank == JACK && isTrumpCard(c, trump) {
+		return 3
+	}
+	if c.suite == trump {
+		return 2
+	}
+	if c.rank == ACE {
+		return 1
+	}
+	return 0
+}
+
+func isTrumpCard(c *Card, trump Suite) bool {
+	return EffectiveSuit(*c, trump) == trump
+}
+
+func trumpCards(cards []*Card, trump Suite) []*Card {
+	trumpCards := make([]*Card, 0)
+	for _, c := range cards {
+		if isTrumpCard(c, trump) {
+			trumpCards = append(trumpCards, c)
+		}
+	}
+	return trumpCards
+}
+
+// cardOrder ranks cards for a trick. Cards that can't win are ordered by rank.
+func cardOrder(c *Card, trump Suite, lead Suite) int {
+	return c.GetPlayValue(trump, lead)*10 + int(c.rank)
+}
+
+func lowestCard(cards []*Card, trump Suite, lead Suite) *Card {
+	var lowest *Card = nil
+	for _, c := range cards {
+		if lowest == nil || cardOrder(c, trump, lead) < cardOrder(lowest, trump, lead) {
+			lowest = c
+		}
+	}
+	return lowest
+}
+
+func highestCard(cards []*Card, trump Suite, lead Suite) *Card {
+	var highest *Card = nil
+	for _, c := range cards {
+		if highest == nil || cardOrder(c, trump, lead) > cardOrder(highest, trump, lead) {
+			highest = c
+		}
+	}
+	return highest
+}
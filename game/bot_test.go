@@ -0,0 +1,293 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleBotOrderUp(t *testing.T) {
+	bot := NewRuleBotController()
+	game := NewGame(DefaultRuleSet())
+	game.DealerIndex = 3
+	game.TurnedCard = &Card{rank: NINE, suite: HEART}
+
+	// both bauers and the ace of trump is worth ordering up
+	player := game.Players[0]
+	player.GiveCards([]*Card{
+		{rank: JACK, suite: HEART},
+		{rank: JACK, suite: DIAMOND},
+		{rank: ACE, suite: HEART},
+		{rank: NINE, suite: CLUB},
+		{rank: TEN, suite: SPADE},
+	})
+	assert.True(t, bot.OrderUp(player, &game), "expected bot to order up a strong hand")
+
+	// a single trump card isn't
+	player = game.Players[1]
+	player.GiveCards([]*Card{
+		{rank: TEN, suite: HEART},
+		{rank: NINE, suite: DIAMOND},
+		{rank: QUEEN, suite: SPADE},
+		{rank: NINE, suite: CLUB},
+		{rank: TEN, suite: SPADE},
+	})
+	assert.False(t, bot.OrderUp(player, &game), "expected bot to pass on a weak hand")
+}
+
+func TestRuleBotPickSuite(t *testing.T) {
+	bot := NewRuleBotController()
+	game := NewGame(DefaultRuleSet())
+	game.TurnedCard = &Card{rank: NINE, suite: HEART}
+
+	player := game.Players[0]
+	player.GiveCards([]*Card{
+		{rank: JACK, suite: SPADE},
+		{rank: JACK, suite: CLUB},
+		{rank: KING, suite: SPADE},
+		{rank: NINE, suite: SPADE},
+		{rank: TEN, suite: HEART},
+	})
+	assert.Equal(t, SPADE, bot.PickSuite(player, &game, false), "expected bot to pick spades")
+
+	// the turned down suite is never picked, even when the dealer is stuck
+	player = game.Players[1]
+	player.GiveCards([]*Card{
+		{rank: JACK, suite: HEART},
+		{rank: ACE, suite: HEART},
+		{rank: NINE, suite: SPADE},
+		{rank: TEN, suite: CLUB},
+		{rank: NINE, suite: DIAMOND},
+	})
+	assert.Equal(t, NONE, bot.PickSuite(player, &game, false), "expected bot to pass")
+	assert.NotEqual(t, HEART, bot.PickSuite(player, &game, true), "expected bot to avoid the turned suite")
+}
+
+func TestRuleBotDiscard(t *testing.T) {
+	bot := NewRuleBotController()
+	game := NewGame(DefaultRuleSet())
+	game.Trump = HEART
+
+	// the lone club should be thrown to short suite the hand
+	lowClub := &Card{rank: TEN, suite: CLUB}
+	player := game.Players[0]
+	player.GiveCards([]*Card{
+		{rank: JACK, suite: HEART},
+		{rank: NINE, suite: HEART},
+		{rank: NINE, suite: SPADE},
+		{rank: KING, suite: SPADE},
+		{rank: ACE, suite: DIAMOND},
+		lowClub,
+	})
+	assert.Equal(t, lowClub, bot.Discard(player, &game))
+}
+
+func TestRuleBotPlayCard(t *testing.T) {
+	bot := NewRuleBotController()
+	game := NewGame(DefaultRuleSet())
+	game.Trump = HEART
+
+	// partner is winning the trick so play low
+	lowSpade := &Card{rank: NINE, suite: SPADE}
+	highSpade := &Card{rank: KING, suite: SPADE}
+	player := game.Players[2]
+	player.GiveCards([]*Card{lowSpade, highSpade, {rank: ACE, suite: CLUB}})
+	game.PlayerIndex = 0
+	game.PlayCard(&Card{rank: ACE, suite: SPADE})
+	game.PlayerIndex = 1
+	game.PlayCard(&Card{rank: TEN, suite: SPADE})
+	game.PlayerIndex = 2
+	assert.Equal(t, lowSpade, bot.PlayCard(player, &game), "expected bot to play low behind its partner")
+
+	// opponent is winning so win the trick as cheaply as possible
+	game.Trick = NewTrick(1)
+	game.PlayerIndex = 1
+	game.PlayCard(&Card{rank: QUEEN, suite: SPADE})
+	game.PlayerIndex = 2
+	assert.Equal(t, highSpade, bot.PlayCard(player, &game), "expected bot to take the trick")
+
+	// lead trump when our team called it
+	rightBauer := &Card{rank: JACK, suite: HEART}
+	player.GiveCard(rightBauer)
+	game.Trick = NewTrick(2)
+	game.OrderedPlayerIndex = 0
+	assert.Equal(t, rightBauer, bot.PlayCard(player, &game), "expected bot to lead trump")
+}
+
+func TestRuleBotsPlayGame(t *testing.T) {
+	game := NewGame(DefaultRuleSet())
+	setRuleBots(&game)
+
+	for steps := 0; game.StateMachine.CurrentState.GetName() != EndGame; steps++ {
+		if steps > 100000 {
+			t.Fatal("game did not finish")
+		}
+		game.StateMachine.Step(&game)
+	}
+}
+
+func TestRuleBotGoAlone(t *testing.T) {
+	bot := NewRuleBotController()
+	game := NewGame(DefaultRuleSet())
+	game.Trump = SPADE
+
+	player := game.Players[0]
+	player.GiveCards([]*Card{
+		{rank: JACK, suite: SPADE},
+		{rank: JACK, suite: CLUB},
+		{rank: ACE, suite: SPADE},
+		{rank: KING, suite: SPADE},
+		{rank: ACE, suite: HEART},
+	})
+	assert.True(t, bot.GoAlone(player, &game), "expected bot to go alone with the top trump")
+
+	player = game.Players[1]
+	player.GiveCards([]*Card{
+		{rank: JACK, suite: SPADE},
+		{rank: NINE, suite: SPADE},
+		{rank: ACE, suite: HEART},
+		{rank: NINE, suite: DIAMOND},
+		{rank: TEN, suite: CLUB},
+	})
+	assert.False(t, bot.GoAlone(player, &game), "expected bot to play with its partner")
+}
+
+// lonerController orders up and goes alone every time it can
+type lonerController struct {
+	RuleBotController
+}
+
+func (lc *lonerController) OrderUp(player *Player, game *Game) bool {
+	return true
+}
+
+func (lc *lonerController) GoAlone(player *Player, game *Game) bool {
+	return true
+}
+
+func TestLonerPartnerSitsOut(t *testing.T) {
+	game := NewGame(DefaultRuleSet())
+	for _, player := range game.Players {
+		player.SetController(&lonerController{})
+	}
+
+	for game.StateMachine.CurrentState.GetName() != CheckForWinner {
+		game.StateMachine.Step(&game)
+		if game.StateMachine.CurrentState.GetName() == GetTrickWinner {
+			assert.Equal(t, 3, game.Trick.Len(), "expected three cards in a loner trick")
+		}
+	}
+
+	assert.NotEqual(t, -1, game.AlonePlayerIndex, "expected a player to go alone")
+	for _, play := range game.HandPlays {
+		assert.False(t, game.IsSittingOut(play.Seat), "expected the partner of the loner to sit out")
+	}
+	for _, player := range game.Players {
+		assert.Empty(t, player.hand, "expected every hand to be returned to the deck")
+	}
+	assert.Equal(t, 24, game.Deck.Length()+game.Trick.Len(), "expected every card to be back in the deck")
+	assertLonerPoints(t, &game)
+}
+
+func TestLonerPoints(t *testing.T) {
+	tests := []struct {
+		name    string
+		hands   [4]string // player 2 goes alone with hearts as trump and player 4 sits out
+		outcome string
+	}{
+		{"loner takes every trick", [4]string{"9S 10S QS KS AS", "JH JD AH KH QH", "9C 10C QC KC AC", "9D 10D QD KD AD"}, "march"},
+		{"loner is euchred", [4]string{"JH JD AH KH QH", "9S 10S 9C 10C 9D", "AS KS AC KC AD", "QS QC 10D QD KD"}, "euchre"},
+	}
+	for _, test := range tests {
+		game := NewGame(DefaultRuleSet())
+		setRuleBots(&game)
+		game.DealerIndex = 0
+		game.makeTrump(1, HEART)
+		game.AlonePlayerIndex = 1
+		for i, text := range test.hands {
+			hand, err := ParseHand(text)
+			assert.NoError(t, err)
+			game.Players[i].hand = cardPointers(hand)
+		}
+
+		game.StateMachine.CurrentState = NewState(StartRound)
+		for game.StateMachine.CurrentState.GetName() != CheckForWinner {
+			game.StateMachine.Step(&game)
+		}
+		assert.Equal(t, test.outcome, assertLonerPoints(t, &game), test.name)
+	}
+}
+
+// assertLonerPoints checks the points awarded for a finished loner hand against
+// the tricks the loner took, and returns how the hand went
+func assertLonerPoints(t *testing.T, game *Game) string {
+	makers := game.MakerTeam()
+	tricks := 0
+	var points []Event
+	for _, event := range game.StateMachine.Events {
+		if event.Type == TrickWonEvent && makers.HasMember(event.Player) {
+			tricks++
+		}
+		if event.Type == PointsEvent {
+			points = append(points, event)
+		}
+	}
+
+	switch {
+	case tricks == 5:
+		assert.Equal(t, []Event{{Type: PointsEvent, Player: -1, Team: makers.Index, Points: 4}}, points, "expected a loner march to earn 4")
+		return "march"
+	case tricks >= 3:
+		assert.Equal(t, []Event{{Type: PointsEvent, Player: -1, Team: makers.Index, Points: 1}}, points, "expected the loner to earn 1")
+		return "made"
+	}
+	defenders := game.DefendingTeams()[0]
+	assert.Equal(t, []Event{{Type: PointsEvent, Player: -1, Team: defenders.Index, Points: 2}}, points, "expected the defenders to earn 2 for euchring a loner")
+	return "euchre"
+}
+
+// passingMonteCarlo is a Monte Carlo bot that never orders up the turned card
+type passingMonteCarlo struct {
+	*MonteCarloController
+}
+
+func (pm passingMonteCarlo) OrderUp(player *Player, game *Game) bool {
+	return false
+}
+
+func TestMonteCarloAgainstDealersPartnerAlone(t *testing.T) {
+	for seed := int64(1); seed <= 5; seed++ {
+		game := NewGame(DefaultRuleSet())
+		game.RandSeed = seed
+		for game.StateMachine.CurrentState.GetName() != ResetDeckAndShuffle {
+			game.StateMachine.Step(&game)
+		}
+
+		// the dealer's partner orders up and goes alone, so the dealer sits out
+		// and the turned card goes back to the deck
+		loner := game.partnerOf(game.DealerIndex)
+		for i, player := range game.Players {
+			if i == loner {
+				player.SetController(&lonerController{})
+			} else {
+				player.SetController(passingMonteCarlo{NewMonteCarloController(20, 0, seed)})
+			}
+		}
+
+		for game.StateMachine.CurrentState.GetName() != CheckForWinner {
+			if game.StateMachine.CurrentState.GetName() == GetPlayerCard && game.PlayerIndex != loner {
+				sampler := newPlaySampler(game.PlayerIndex, &game)
+				rng := rand.New(rand.NewSource(seed))
+				for i := 0; i < 20; i++ {
+					assert.NotContains(t, sampler.sample(rng)[game.DealerIndex], game.RevealedCard, "expected the turned card to be out of play")
+				}
+			}
+			game.StateMachine.Step(&game)
+		}
+
+		assert.Equal(t, loner, game.AlonePlayerIndex)
+		assert.True(t, game.IsSittingOut(game.DealerIndex))
+		assertLonerPoints(t, &game)
+	}
+}
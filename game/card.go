@@ -1,6 +1,10 @@
 package game
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
 
 type Suite int
 
@@ -127,6 +131,149 @@ func (c *Card) ToString() string {
 	return fmt.Sprintf("%s of %s", c.rank.ToString(), c.suite.ToString())
 }
 
+// Cards, ranks and suites are written in a short notation: a rank of 9, 10, J,
+// Q, K or A followed by a suite of D, C, H or S, like JH or 10S. Parsing ignores
+// case and also accepts T for 10.
+
+func (s Suite) String() string {
+	switch s {
+	case DIAMOND:
+		return "D"
+	case CLUB:
+		return "C"
+	case HEART:
+		return "H"
+	case SPADE:
+		return "S"
+	}
+	return "-"
+}
+
+// ParseSuite reads a suite written by Suite.String. "-" and "" are NONE.
+func ParseSuite(text string) (Suite, error) {
+	switch strings.ToUpper(strings.TrimSpace(text)) {
+	case "D":
+		return DIAMOND, nil
+	case "C":
+		return CLUB, nil
+	case "H":
+		return HEART, nil
+	case "S":
+		return SPADE, nil
+	case "-", "":
+		return NONE, nil
+	}
+	return NONE, fmt.Errorf("invalid suite %q", text)
+}
+
+func (s Suite) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *Suite) UnmarshalText(text []byte) error {
+	suite, err := ParseSuite(string(text))
+	if err != nil {
+		return err
+	}
+	*s = suite
+	return nil
+}
+
+func (r Rank) String() string {
+	return r.ToChar()
+}
+
+// ParseRank reads a rank written by Rank.String
+func ParseRank(text string) (Rank, error) {
+	switch strings.ToUpper(strings.TrimSpace(text)) {
+	case "9":
+		return NINE, nil
+	case "10", "T":
+		return TEN, nil
+	case "J":
+		return JACK, nil
+	case "Q":
+		return QUEEN, nil
+	case "K":
+		return KING, nil
+	case "A":
+		return ACE, nil
+	}
+	return NINE, fmt.Errorf("invalid rank %q", text)
+}
+
+func (r Rank) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+func (r *Rank) UnmarshalText(text []byte) error {
+	rank, err := ParseRank(string(text))
+	if err != nil {
+		return err
+	}
+	*r = rank
+	return nil
+}
+
+func (c Card) String() string {
+	return c.rank.String() + c.suite.String()
+}
+
+// ParseCard reads a card written like JH, 10s or 9d
+func ParseCard(text string) (Card, error) {
+	card, err := parseCard(text)
+	if err == nil && card.suite == NONE {
+		return Card{}, fmt.Errorf("invalid card %q: missing suite", text)
+	}
+	return card, err
+}
+
+// parseCard reads a card that may have no suite, like the zero Card written as 9-
+func parseCard(text string) (Card, error) {
+	text = strings.TrimSpace(text)
+	if len(text) < 2 {
+		return Card{}, fmt.Errorf("invalid card %q", text)
+	}
+	rank, err := ParseRank(text[:len(text)-1])
+	if err != nil {
+		return Card{}, fmt.Errorf("invalid card %q: %w", text, err)
+	}
+	suite, err := ParseSuite(text[len(text)-1:])
+	if err != nil {
+		return Card{}, fmt.Errorf("invalid card %q: %w", text, err)
+	}
+	return Card{rank: rank, suite: suite}, nil
+}
+
+// ParseHand reads cards separated by spaces or commas, like "JH JD AH KS 9C"
+func ParseHand(text string) ([]Card, error) {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	cards := make([]Card, len(fields))
+	for i, field := range fields {
+		card, err := ParseCard(field)
+		if err != nil {
+			return nil, err
+		}
+		cards[i] = card
+	}
+	return cards, nil
+}
+
+func (c Card) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+func (c *Card) UnmarshalText(text []byte) error {
+	card, err := parseCard(string(text))
+	if err != nil {
+		return err
+	}
+	*c = card
+	return nil
+}
+
 func IntToSuite(s int) Suite {
 	switch s {
 	case 0:
@@ -163,28 +310,34 @@ func (c *Card) IsLeftBauer(trump Suite) bool {
 	return c.suite == LeftBauerSuite[trump] && c.rank == JACK
 }
 
+// EffectiveSuit returns the suite a card counts as once trump is known. The left
+// bauer counts as trump instead of the suite printed on it. Every other card,
+// and every card before trump is picked, is its printed suite.
+func EffectiveSuit(c Card, trump Suite) Suite {
+	if trump != NONE && c.IsLeftBauer(trump) {
+		return trump
+	}
+	return c.suite
+}
+
 func (c *Card) GetPlayValue(trump Suite, lead Suite) int {
 	value := 1 // start at 1 because we have some value if trump or lead
 
-	if c.suite != trump && c.suite != lead && !c.IsLeftBauer(trump) {
+	suite := EffectiveSuit(*c, trump)
+	if suite != trump && suite != lead {
 		return 0
 	}
 
-	if c.suite == trump {
+	if suite == trump {
 		value += 10
 
-		// if right bauer
-		if c.rank == JACK {
-			value += 5
+		if c.rank == JACK && c.suite == trump {
+			value += 5 // right bauer
+		} else if c.rank == JACK {
+			value += 4 // left bauer
 		}
 	}
 
-	// check for left bauer
-	if c.IsLeftBauer(trump) {
-		value += 10 // 10 pts for left suite
-		value += 4  // 4 pts for being left bauer
-	}
-
 	value += int(c.rank)
 	return value
 }
@@ -213,59 +366,25 @@ func GetWinningCard(c1 Card, c2 Card, c3 Card, c4 Card, trump Suite, lead Suite)
 	return winner
 }
 
+// GetPlayableCards returns the cards in hand that follow the effective suite of
+// the lead card, or the whole hand if none do
 func GetPlayableCards(hand []*Card, trump Suite, lead *Card) []*Card {
 	if lead == nil {
 		return hand
 	}
 
+	leadSuite := EffectiveSuit(*lead, trump)
 	var playableCards = make([]*Card, 0)
-
-	// was the left bauer led?
-	leftBauerWasLed := lead.IsLeftBauer(trump)
-
-	if leftBauerWasLed {
-		// the lead suite is actually the trump suite in this case
-		lead.suite = trump
-	}
-
-	// was trump led?
-	trumpWasLed := lead.suite == trump || leftBauerWasLed
-
-	// if trump was led, we must play trump if we have it
-	hasTrumpCards := false
-	if trumpWasLed {
-		for _, c := range hand {
-			if c.suite == trump || c.IsLeftBauer(trump) {
-				playableCards = append(playableCards, c)
-				hasTrumpCards = true
-			}
-		}
-	}
-
-	if hasTrumpCards {
-		return playableCards
-	}
-
-	// if trump was not led, we must play lead if we have it
-	hasLeadCards := false
-	// check if any cards match what was lead
 	for _, c := range hand {
-		// left bauer is a different suite than it shows
-		if c.IsLeftBauer(trump) {
-			continue
-		}
-
-		if c.suite == lead.suite {
+		if EffectiveSuit(*c, trump) == leadSuite {
 			playableCards = append(playableCards, c)
-			hasLeadCards = true
 		}
 	}
 
-	// if we don't have trump or lead cards, all cards are valid
-	if !hasLeadCards && !hasTrumpCards {
+	// if we can't follow suite, all cards are valid
+	if len(playableCards) == 0 {
 		playableCards = append(playableCards, hand...)
 	}
-
 	return playableCards
 }
 
@@ -1,6 +1,8 @@
 package game
 
 import (
+	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -133,3 +135,158 @@ func TestGetPlayableCards(t *testing.T) {
 	assert.Equal(t, len(playableCards), 1, "Expected 1 cards to be returned")
 	assert.Contains(t, playableCards, &Card{rank: JACK, suite: SPADE}, "Expected jack of spades to be returned")
 }
+
+func TestEffectiveSuit(t *testing.T) {
+	tests := []struct {
+		card  string
+		trump Suite
+		suite Suite
+	}{
+		{"JH", HEART, HEART},     // right bauer
+		{"JD", HEART, HEART},     // left bauer
+		{"JC", HEART, CLUB},      // jack of the other color
+		{"JS", HEART, SPADE},     // jack of the other color
+		{"JC", SPADE, SPADE},     // left bauer
+		{"JS", CLUB, CLUB},       // left bauer
+		{"JH", DIAMOND, DIAMOND}, // left bauer
+		{"AD", HEART, DIAMOND},   // the left bauer's suite isn't trump
+		{"9H", HEART, HEART},
+		{"JD", NONE, DIAMOND}, // no bauers before trump is picked
+	}
+	for _, test := range tests {
+		card := mustParseCard(t, test.card)
+		assert.Equal(t, test.suite, EffectiveSuit(card, test.trump), "%s with %s trump", test.card, test.trump.ToString())
+		assert.Equal(t, mustParseCard(t, test.card), card, "expected %s not to change", test.card)
+	}
+}
+
+func TestFollowingSuiteWithBauers(t *testing.T) {
+	tests := []struct {
+		name     string
+		trump    Suite
+		lead     string
+		hand     string
+		playable string
+	}{
+		{"left led, must follow with trump", HEART, "JD", "JH 9D AS", "JH"},
+		{"left led, its printed suite doesn't follow", HEART, "JD", "9H AD KD", "9H"},
+		{"left led, no trump to follow with", HEART, "JD", "AD KS 9C", "AD KS 9C"},
+		{"right led, left follows", HEART, "JH", "JD AD 9S", "JD"},
+		{"trump led, only the left follows", CLUB, "AC", "9D TD JS KD AD", "JS"},
+		{"left's printed suite led, left doesn't follow", HEART, "AD", "JD AS 9C", "JD AS 9C"},
+		{"left's printed suite led, other cards follow", HEART, "AD", "JD 9D AS", "9D"},
+		{"off jack follows its own suite", HEART, "AC", "JC 9S JD", "JC"},
+		{"off jack led is its own suite", HEART, "JC", "AC JD 9H", "AC"},
+		{"trump led, left and trump follow", SPADE, "9S", "JC AS AC", "JC AS"},
+	}
+	for _, test := range tests {
+		lead := mustParseCard(t, test.lead)
+		hand, err := ParseHand(test.hand)
+		assert.NoError(t, err)
+		expected, err := ParseHand(test.playable)
+		assert.NoError(t, err)
+
+		playable := GetPlayableCards(cardPointers(hand), test.trump, &lead)
+		assert.ElementsMatch(t, expected, cardValues(playable), test.name)
+		assert.Equal(t, mustParseCard(t, test.lead), lead, "%s: expected the lead card not to change", test.name)
+	}
+}
+
+func TestTrickWinnerWithBauers(t *testing.T) {
+	tests := []struct {
+		name   string
+		trump  Suite
+		trick  string
+		winner string
+	}{
+		{"right beats left", HEART, "JD JH AH KH", "JH"},
+		{"left beats ace of trump", HEART, "AH JD KH QH", "JD"},
+		{"left led beats ace of its printed suite", HEART, "JD AD KD QD", "JD"},
+		{"left trumps its printed suite", HEART, "AD KD JD QD", "JD"},
+		{"left led is trump", SPADE, "JC AC AS KS", "JC"},
+		{"off jack isn't a bauer", HEART, "AC JS JC 9D", "AC"},
+		{"lowest trump beats the lead", DIAMOND, "AS KS QS 9D", "9D"},
+		{"lead wins without trump", CLUB, "TH AD AH 9H", "AH"},
+	}
+	for _, test := range tests {
+		trick, err := ParseHand(test.trick)
+		assert.NoError(t, err)
+
+		lead := EffectiveSuit(trick[0], test.trump)
+		winner := GetWinningCard(trick[0], trick[1], trick[2], trick[3], test.trump, lead)
+		assert.Equal(t, mustParseCard(t, test.winner), winner, test.name)
+	}
+}
+
+func TestParseCard(t *testing.T) {
+	tests := []struct {
+		text string
+		card Card
+	}{
+		{"JH", Card{JACK, HEART}},
+		{"10s", Card{TEN, SPADE}},
+		{"9d", Card{NINE, DIAMOND}},
+		{"tc", Card{TEN, CLUB}},
+		{" AS ", Card{ACE, SPADE}},
+		{"qh", Card{QUEEN, HEART}},
+		{"Kc", Card{KING, CLUB}},
+	}
+	for _, test := range tests {
+		card, err := ParseCard(test.text)
+		assert.NoError(t, err, test.text)
+		assert.Equal(t, test.card, card, test.text)
+	}
+
+	for _, text := range []string{"", "J", "JX", "8H", "11S", "9-", "Jack of Hearts"} {
+		_, err := ParseCard(text)
+		assert.Error(t, err, "expected %q to be invalid", text)
+	}
+}
+
+func TestParseHand(t *testing.T) {
+	hand, err := ParseHand("JH JD AH, KS 9C")
+	assert.NoError(t, err)
+	assert.Equal(t, []Card{{JACK, HEART}, {JACK, DIAMOND}, {ACE, HEART}, {KING, SPADE}, {NINE, CLUB}}, hand)
+
+	hand, err = ParseHand("")
+	assert.NoError(t, err)
+	assert.Empty(t, hand)
+
+	_, err = ParseHand("JH ZZ")
+	assert.Error(t, err)
+}
+
+func TestCardNotationRoundTrips(t *testing.T) {
+	for _, card := range allCards() {
+		assert.Equal(t, card, mustParseCard(t, card.String()))
+
+		text, err := card.MarshalText()
+		assert.NoError(t, err)
+		var parsed Card
+		assert.NoError(t, parsed.UnmarshalText(text))
+		assert.Equal(t, card, parsed)
+	}
+	assert.Equal(t, "10S", fmt.Sprint(Card{TEN, SPADE}))
+	assert.Equal(t, "J", fmt.Sprint(JACK))
+	assert.Equal(t, "H", fmt.Sprint(HEART))
+	assert.Equal(t, "-", fmt.Sprint(NONE))
+}
+
+func mustParseCard(t *testing.T, text string) Card {
+	card, err := ParseCard(text)
+	assert.NoError(t, err)
+	return card
+}
+
+func TestCardJSON(t *testing.T) {
+	data, err := json.Marshal(Event{Type: PickSuiteEvent, Cards: []Card{{JACK, HEART}}, Suite: CLUB})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type":"PickSuite","player":0,"cards":["JH"],"suite":"C"}`, string(data))
+
+	var event Event
+	assert.NoError(t, json.Unmarshal(data, &event))
+	assert.Equal(t, []Card{{JACK, HEART}}, event.Cards)
+	assert.Equal(t, CLUB, event.Suite)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"cards":["XH"]}`), &event))
+}
@@ -0,0 +1,166 @@
+package game
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+)
+
+// Connect joins a game hosted with Serve and plays it in this terminal
+func Connect(address string, name string) error {
+	conn, err := net.Dial("tcp", address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	display, err := NewTextDisplay()
+	if err != nil {
+		return err
+	}
+	defer display.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		show := func(text string) {
+			display.AddLogs(text)
+			display.ShowMessage(text)
+		}
+		done <- playRemote(conn, name, display.DrawBoard, show, display.ReadLine)
+	}()
+
+	select {
+	case err = <-done:
+	case <-display.Interrupted():
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	display.ShowMessage("Game over! Press Ctrl-C to exit.")
+	<-display.Interrupted()
+	return nil
+}
+
+// playRemote draws each view of the game sent by the server, shows its logs and
+// answers its prompts with ask
+func playRemote(conn io.ReadWriter, name string, draw func(PlayerView), show func(string), ask func(string) string) error {
+	if _, err := fmt.Fprintf(conn, "HELLO %s\n", name); err != nil {
+		return err
+	}
+
+	shuffle := shuffleCheck{}
+	messages := bufio.NewScanner(conn)
+	// views are a lot longer than the default max line length
+	messages.Buffer(make([]byte, 0, 4096), 1024*1024)
+	for messages.Scan() {
+		kind, args, _ := strings.Cut(messages.Text(), " ")
+		switch kind {
+		case "WELCOME":
+			seat, name, _ := strings.Cut(args, " ")
+			show(fmt.Sprintf("Welcome %s! You're sitting in seat %s.", name, seat))
+		case "VIEW":
+			view := PlayerView{}
+			if err := json.Unmarshal([]byte(args), &view); err != nil {
+				return fmt.Errorf("invalid view from server: %w", err)
+			}
+			shuffle.see(view)
+			draw(view)
+		case "LOG":
+			show(args)
+		case "PROMPT":
+			_, text, _ := strings.Cut(args, " ")
+			answer := ask(text + ": ")
+			if _, err := fmt.Fprintf(conn, "%s\n", answer); err != nil {
+				return err
+			}
+		case "INVALID":
+			show("Received invalid input!")
+		case "COMMIT":
+			shuffle = shuffleCheck{commitment: args}
+		case "ENTROPY":
+			entropy := make([]byte, 16)
+			if _, err := rand.Read(entropy); err != nil {
+				return err
+			}
+			shuffle.entropy = hex.EncodeToString(entropy)
+			if _, err := fmt.Fprintf(conn, "%s\n", shuffle.entropy); err != nil {
+				return err
+			}
+		case "REVEAL":
+			if err := shuffle.verify(args); err != nil {
+				show(fmt.Sprintf("The shuffle could not be verified: %s", err))
+			} else {
+				show("The shuffle was verified")
+			}
+		case "END":
+			return nil
+		}
+	}
+
+	if err := messages.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("lost connection to the server")
+}
+
+// shuffleCheck remembers what a client needs to check a shuffle when it's
+// revealed: the commitment, the entropy the client added and the hand it was
+// dealt
+type shuffleCheck struct {
+	commitment string
+	entropy    string
+	dealt      []Card
+	players    int
+	dealer     int
+	seat       int
+}
+
+// see records the client's hand the first time it's dealt in full after the
+// commitment
+func (sc *shuffleCheck) see(view PlayerView) {
+	if sc.commitment != "" && sc.dealt == nil && view.Seat >= 0 && len(view.Hand) == 5 {
+		sc.dealt = append([]Card{}, view.Hand...)
+		sc.players = len(view.Names)
+		sc.dealer = view.DealerIndex
+		sc.seat = view.Seat
+	}
+}
+
+// verify checks a reveal against the commitment, the client's entropy and the
+// hand the client was dealt
+func (sc *shuffleCheck) verify(reveal string) error {
+	fields := strings.Fields(reveal)
+	if len(fields) == 0 || fields[0] != sc.commitment {
+		return fmt.Errorf("it wasn't the shuffle that was committed to")
+	}
+	deck, err := VerifyReveal(reveal)
+	if err != nil {
+		return err
+	}
+	if sc.entropy != "" && !containsString(fields[2:], sc.entropy) {
+		return fmt.Errorf("our entropy was left out")
+	}
+	if sc.dealt != nil {
+		for _, c := range dealtHand(deck, sc.players, sc.dealer, sc.seat) {
+			if !containsCard(sc.dealt, c) {
+				return fmt.Errorf("we weren't dealt %s", c)
+			}
+		}
+	}
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package game
+
+// PlayerController makes the decisions for a seat at the table. Every Player has
+// one, so a seat can be filled by a human at this terminal, a bot, a remote client
+// or a test script without the states knowing the difference.
+type PlayerController interface {
+	// OrderUp is asked during the first round of trump selection. Returning true
+	// orders the turned card up as trump.
+	OrderUp(player *Player, game *Game) bool
+
+	// PickSuite is asked during the second round of trump selection. The suite of
+	// the turned card can't be picked. Returning NONE passes, which is only allowed
+	// when mustPick is false.
+	PickSuite(player *Player, game *Game, mustPick bool) Suite
+
+	// GoAlone is asked of the player that made trump. Returning true has their
+	// partner sit out the hand.
+	GoAlone(player *Player, game *Game) bool
+
+	// Discard is asked after the dealer picks up the turned card. The returned card
+	// must be in the player's hand.
+	Discard(player *Player, game *Game) *Card
+
+	// PlayCard returns the card from the player's hand they want to play.
+	PlayCard(player *Player, game *Game) *Card
+}
+
+// TerminalController prompts a human at this terminal for every decision
+type TerminalController struct{}
+
+func NewTerminalController() *TerminalController {
+	return &TerminalController{}
+}
+
+func (tc *TerminalController) OrderUp(player *Player, game *Game) bool {
+	return GetTrumpSelectionOneInput(player, *game.TurnedCard)
+}
+
+func (tc *TerminalController) PickSuite(player *Player, game *Game, mustPick bool) Suite {
+	if mustPick {
+		return GetScrewTheDealerInput(player, *game.TurnedCard)
+	}
+	return GetTrumpSelectionTwoInput(player, *game.TurnedCard)
+}
+
+func (tc *TerminalController) GoAlone(player *Player, game *Game) bool {
+	return GetGoAloneInput(player)
+}
+
+func (tc *TerminalController) Discard(player *Player, game *Game) *Card {
+	discardable := player.hand
+	if game.Rules.DealerMustPickUp {
+		discardable = make([]*Card, 0)
+		for _, c := range player.hand {
+			if c != game.TurnedCard {
+				discardable = append(discardable, c)
+			}
+		}
+	}
+	return GetDealersBurnCard(player, discardable)
+}
+
+func (tc *TerminalController) PlayCard(player *Player, game *Game) *Card {
+	return GetCardInput(player, GetPlayableCards(player.hand, game.Trump, game.Trick.LeadCard()))
+}
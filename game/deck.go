@@ -1,12 +1,16 @@
 package game
 
 import (
+	"fmt"
 	"math/rand"
+	"sort"
+	"time"
 )
 
 type Deck struct {
 	cards       []*Card
 	ShuffleSeed int64
+	fair        *FairShuffle // a shuffle that was committed to for the next Shuffle
 }
 
 func InitDeck(shuffleSeed int64) Deck {
@@ -21,7 +25,42 @@ func InitDeck(shuffleSeed int64) Deck {
 	return deck
 }
 
+// NewSeed picks a master seed from the time for a game that wasn't given one
+func NewSeed() int64 {
+	return time.Now().UnixNano()
+}
+
+// NextSeed derives the seed of a shuffle from the seed of the shuffle before it,
+// or from the game's master seed for the first one. Every hand gets its own seed
+// so hands differ, but the whole game can be reproduced from the master seed and
+// a game picked up from its log carries on with the same seeds.
+func NextSeed(seed int64) int64 {
+	// a splitmix64 step, so nearby seeds give unrelated ones
+	z := uint64(seed) + 0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return int64(z ^ (z >> 31))
+}
+
+// CommitShuffle makes the next Shuffle use the seed of a fair shuffle instead of
+// ShuffleSeed
+func (d *Deck) CommitShuffle(fair *FairShuffle) {
+	d.fair = fair
+}
+
+// Shuffle puts the deck in an order decided only by ShuffleSeed, or by the seed
+// of the fair shuffle committed to with CommitShuffle. The cards are sorted
+// first, so the same seed always gives the same order no matter how the cards
+// were returned to the deck.
 func (d *Deck) Shuffle() {
+	if d.fair != nil {
+		d.ShuffleSeed = d.fair.Seed()
+		d.fair = nil
+	}
+
+	sort.Slice(d.cards, func(i, j int) bool {
+		return deckPosition(d.cards[i]) < deckPosition(d.cards[j])
+	})
 	rng := rand.New(rand.NewSource(d.ShuffleSeed))
 
 	n := len(d.cards)
@@ -31,6 +70,42 @@ func (d *Deck) Shuffle() {
 	}
 }
 
+// deckPosition is the position of the card in a new deck made by InitDeck
+func deckPosition(c *Card) int {
+	return (int(c.suite)-1)*6 + int(c.rank)
+}
+
+// Cards returns the cards in the deck in order. The top card is last
+func (d *Deck) Cards() []Card {
+	return cardValues(d.cards)
+}
+
+// Arrange puts the cards in the deck in the given order. The order must contain
+// exactly the cards that are in the deck.
+func (d *Deck) Arrange(order []Card) error {
+	if len(order) != len(d.cards) {
+		return fmt.Errorf("can't arrange %d cards in a deck of %d", len(order), len(d.cards))
+	}
+
+	arranged := make([]*Card, 0, len(order))
+	remaining := append([]*Card{}, d.cards...)
+	for _, c := range order {
+		card := findCard(remaining, c)
+		if card == nil {
+			return fmt.Errorf("%s is not in the deck", c.ToString())
+		}
+		arranged = append(arranged, card)
+		for i, r := range remaining {
+			if r == card {
+				remaining = append(remaining[:i], remaining[i+1:]...)
+				break
+			}
+		}
+	}
+	d.cards = arranged
+	return nil
+}
+
 func (d *Deck) Length() int {
 	return len(d.cards)
 }
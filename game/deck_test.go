@@ -66,3 +66,19 @@ func TestShuffle(t *testing.T) {
 	deck := InitDeck(0)
 	deck.Shuffle()
 }
+
+func TestShuffleOrderDependsOnlyOnSeed(t *testing.T) {
+	deck := InitDeck(42)
+	deck.Shuffle()
+
+	// the same seed gives the same order even when the cards start out of order
+	other := InitDeck(7)
+	other.Shuffle()
+	other.ShuffleSeed = 42
+	other.Shuffle()
+	assert.Equal(t, deck.Cards(), other.Cards())
+
+	other.ShuffleSeed = NextSeed(42)
+	other.Shuffle()
+	assert.NotEqual(t, deck.Cards(), other.Cards())
+}
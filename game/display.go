@@ -2,92 +2,405 @@ package game
 
 import (
 	"fmt"
-	"os"
-	"os/exec"
-	"runtime"
+	"sync"
 
-	"github.com/fatih/color"
+	"github.com/gdamore/tcell/v2"
 )
 
-const DISPLAY_WIDTH = 166
-const DISPLAY_HEIGHT = 51
-
+// TextDisplay is the full screen terminal UI. It draws the board from a
+// PlayerView, redraws it when the terminal is resized, and reads answers typed
+// on the input line at the bottom of the screen.
 type TextDisplay struct {
-	width  int
-	height int
-	grid   [][]string
+	screen      tcell.Screen
+	lock        sync.Mutex
+	view        *PlayerView // the last view drawn, kept so the board can be redrawn
+	message     string      // shown on the line above the input line
+	logs        []string    // the game log shown in the history panel
+	panelScroll int         // how many lines the history panel is scrolled back
+	showPanel   bool        // the compact board shows the history panel instead of the table
+	curtain     string      // shown instead of the board while the terminal is passed to another player
+	prompt      string
+	input       []rune
+	reading     bool // true while waiting for the player to press Enter
+	answers     chan inputAnswer
+	interrupted chan struct{}
+
+	// while a card is being selected, the cards being picked from are drawn in
+	// the selecting seat's place instead of what the view has for that seat
+	selecting  bool
+	selectSeat int
+	choices    []Card
+	selectable []bool
+	selected   int
+	cardBounds []cardBounds // where each choice is on the screen, for mouse clicks
+}
+
+// inputAnswer is what the player entered on the input line. index is the card
+// they selected, or -1 if they typed an answer instead.
+type inputAnswer struct {
+	index int
+	text  string
 }
 
-func NewTextDisplay() *TextDisplay {
-	ClearTerminal()
+type cardBounds struct {
+	x, y, width, height int
+}
+
+func NewTextDisplay() (*TextDisplay, error) {
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		return nil, err
+	}
+	return newTextDisplay(screen)
+}
+
+func newTextDisplay(screen tcell.Screen) (*TextDisplay, error) {
+	if err := screen.Init(); err != nil {
+		return nil, err
+	}
+	screen.EnableMouse()
 	t := TextDisplay{}
-	t.width = DISPLAY_WIDTH
-	t.height = DISPLAY_HEIGHT
-	t.grid = make([][]string, DISPLAY_HEIGHT)
-	for i := 0; i < DISPLAY_HEIGHT; i++ {
-		t.grid[i] = make([]string, DISPLAY_WIDTH)
-	}
-	return &t
-}
-
-func (t *TextDisplay) Render() {
-	moveCursorHome()
-	for _, row := range t.grid {
-		// combine the row into a string
-		rowString := ""
-		for _, cell := range row {
-			rowString += cell
+	t.screen = screen
+	t.answers = make(chan inputAnswer, 1)
+	t.interrupted = make(chan struct{}, 1)
+	go t.pollEvents()
+	return &t, nil
+}
+
+// Close gives the terminal back
+func (t *TextDisplay) Close() {
+	t.screen.Fini()
+}
+
+// Interrupted receives when the player presses Ctrl-C. The terminal doesn't send
+// SIGINT while the display is open.
+func (t *TextDisplay) Interrupted() <-chan struct{} {
+	return t.interrupted
+}
+
+func (t *TextDisplay) pollEvents() {
+	for {
+		switch event := t.screen.PollEvent().(type) {
+		case nil:
+			// the screen was closed
+			return
+		case *tcell.EventResize:
+			t.lock.Lock()
+			t.screen.Sync()
+			t.redraw()
+			t.lock.Unlock()
+		case *tcell.EventKey:
+			t.handleKey(event)
+		case *tcell.EventMouse:
+			t.handleMouse(event)
 		}
-		fmt.Print(rowString)
+	}
+}
 
-		fmt.Print("\n")
+func (t *TextDisplay) handleKey(event *tcell.EventKey) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	switch event.Key() {
+	case tcell.KeyCtrlC:
+		select {
+		case t.interrupted <- struct{}{}:
+		default:
+		}
+		return
+	case tcell.KeyEnter:
+		if !t.reading {
+			return
+		}
+		if t.selecting && len(t.input) == 0 {
+			t.answer(inputAnswer{index: t.selected})
+		} else {
+			t.answer(inputAnswer{index: -1, text: string(t.input)})
+		}
+	case tcell.KeyPgUp:
+		t.scrollPanel(10)
+	case tcell.KeyPgDn:
+		t.scrollPanel(-10)
+	case tcell.KeyF2:
+		t.showPanel = !t.showPanel
+	case tcell.KeyLeft, tcell.KeyUp, tcell.KeyBacktab:
+		t.moveSelection(-1)
+	case tcell.KeyRight, tcell.KeyDown, tcell.KeyTab:
+		t.moveSelection(1)
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if len(t.input) > 0 {
+			t.input = t.input[:len(t.input)-1]
+		}
+	case tcell.KeyRune:
+		if t.reading {
+			t.input = append(t.input, event.Rune())
+		}
 	}
-	fmt.Println()
+	t.redraw()
 }
 
-func (t *TextDisplay) ClearDisplay() {
-	for i := 0; i < t.height; i++ {
-		for j := 0; j < t.width; j++ {
-			t.grid[i][j] = " "
+func (t *TextDisplay) handleMouse(event *tcell.EventMouse) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	switch {
+	case event.Buttons()&tcell.WheelUp != 0:
+		t.scrollPanel(1)
+		t.redraw()
+		return
+	case event.Buttons()&tcell.WheelDown != 0:
+		t.scrollPanel(-1)
+		t.redraw()
+		return
+	}
+
+	if !t.selecting || event.Buttons()&tcell.Button1 == 0 {
+		return
+	}
+	x, y := event.Position()
+	for i, bounds := range t.cardBounds {
+		inside := x >= bounds.x && x < bounds.x+bounds.width && y >= bounds.y && y < bounds.y+bounds.height
+		if inside && t.selectable[i] {
+			t.answer(inputAnswer{index: i})
+			t.redraw()
+			return
+		}
+	}
+}
+
+// answer sends what the player entered to whoever is waiting for it. The lock
+// must be held.
+func (t *TextDisplay) answer(answer inputAnswer) {
+	t.answers <- answer
+	t.reading = false
+	t.selecting = false
+	t.prompt = ""
+	t.input = nil
+}
+
+// moveSelection moves the highlight to the next card that can be selected in
+// the given direction. The lock must be held.
+func (t *TextDisplay) moveSelection(direction int) {
+	if !t.selecting {
+		return
+	}
+	count := len(t.choices)
+	for i := 1; i <= count; i++ {
+		index := ((t.selected+direction*i)%count + count) % count
+		if t.selectable[index] {
+			t.selected = index
+			return
 		}
 	}
 }
 
+// ReadLine shows prompt on the input line and waits for the player to type an
+// answer and press Enter
+func (t *TextDisplay) ReadLine(prompt string) string {
+	t.lock.Lock()
+	t.prompt = prompt
+	t.input = nil
+	t.reading = true
+	t.redraw()
+	t.lock.Unlock()
+
+	return (<-t.answers).text
+}
+
+// SelectCard has the player in seat pick one of cards with the arrow keys, tab,
+// Enter or the mouse. Only the selectable cards can be picked, the rest are
+// greyed out. It returns the index of the card picked, or -1 and what the player
+// typed if they typed an answer instead.
+func (t *TextDisplay) SelectCard(seat int, cards []Card, selectable []bool, prompt string) (int, string) {
+	t.lock.Lock()
+	t.selecting = true
+	t.selectSeat = seat
+	t.choices = cards
+	t.selectable = selectable
+	t.selected = len(cards) - 1
+	t.moveSelection(1)
+	t.prompt = prompt
+	t.input = nil
+	t.reading = true
+	t.redraw()
+	t.lock.Unlock()
+
+	answer := <-t.answers
+	return answer.index, answer.text
+}
+
+// PassTo hides the board behind text until Enter is pressed, so the terminal can
+// be passed to another player without them seeing the last player's hand
+func (t *TextDisplay) PassTo(text string) {
+	t.lock.Lock()
+	t.curtain = text
+	t.input = nil
+	t.reading = true
+	t.redraw()
+	t.lock.Unlock()
+
+	<-t.answers
+
+	t.lock.Lock()
+	t.curtain = ""
+	t.redraw()
+	t.lock.Unlock()
+}
+
+// ShowMessage shows text on the line above the input line
+func (t *TextDisplay) ShowMessage(text string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.message = text
+	t.redraw()
+}
+
+// AddLogs adds lines to the game log in the history panel
+func (t *TextDisplay) AddLogs(logs ...string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.logs = append(t.logs, logs...)
+	t.redraw()
+}
+
+// DrawBoard draws the game as seen by one seat at the table
+func (t *TextDisplay) DrawBoard(view PlayerView) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.view = &view
+	t.redraw()
+}
+
+// redraw draws the whole screen. The lock must be held.
+func (t *TextDisplay) redraw() {
+	t.screen.Clear()
+	if t.curtain != "" {
+		t.DrawCurtain()
+		t.screen.Show()
+		return
+	}
+
+	// the bottom two lines are for the message and the input line
+	width, height := t.screen.Size()
+	height -= 2
+	compact := t.view != nil && isCompact(width, height, len(t.view.Names))
+	if t.view != nil && t.view.State != InitGame && compact && t.showPanel {
+		t.DrawHistoryPanel(1, 0, width-2, height, *t.view)
+	} else if t.view != nil && t.view.State != InitGame && compact {
+		t.DrawCompactBoard(*t.view, width, height)
+	} else if t.view != nil && t.view.State != InitGame {
+		board := newFullBoard(width)
+		t.DrawBounds(width, height, board)
+		t.DrawPlayerHands(*t.view)
+		t.DrawDealerArrow(*t.view)
+		t.DrawTurnArrow(*t.view)
+		t.DrawPlayedCards(board.played+5, *t.view)
+		t.DrawTurnedCard(board.turned+5, *t.view)
+		t.DrawStats(board.stats+5, *t.view)
+		t.DrawHorizontalLine(board.stats+1, 19, width-board.stats-2)
+		t.DrawHistoryPanel(board.stats+2, 20, width-board.stats-3, height-21, *t.view)
+	}
+	t.DrawInputLine()
+	t.screen.Show()
+}
+
+// DrawCurtain draws the curtain text in the middle of an otherwise blank screen
+func (t *TextDisplay) DrawCurtain() {
+	width, height := t.screen.Size()
+	x := maxInt((width-len([]rune(t.curtain)))/2, 0)
+	t.DrawText(x, height/2, t.curtain)
+	t.screen.HideCursor()
+}
+
+// DrawInputLine draws the message, the prompt and what has been typed so far at
+// the bottom of the screen
+func (t *TextDisplay) DrawInputLine() {
+	_, height := t.screen.Size()
+	t.DrawText(2, height-2, t.message)
+	if !t.reading {
+		t.screen.HideCursor()
+		return
+	}
+
+	line := fmt.Sprintf("> %s%s", t.prompt, string(t.input))
+	t.DrawText(2, height-1, line)
+	t.screen.ShowCursor(2+len([]rune(line)), height-1)
+}
+
 func (t *TextDisplay) DrawRune(x, y int, r rune) {
-	t.grid[y][x] = string(r)
+	t.screen.SetContent(x, y, r, nil, tcell.StyleDefault)
 }
 
 func (t *TextDisplay) DrawVerticalLine(x, y, length int) {
 	for i := 0; i < length; i++ {
-		t.grid[y+i][x] = "│"
+		t.DrawRune(x, y+i, '│')
 	}
 }
 
 func (t *TextDisplay) DrawHorizontalLine(x, y, length int) {
 	for i := 0; i < length; i++ {
-		t.grid[y][x+i] = "─"
+		t.DrawRune(x+i, y, '─')
 	}
 }
 
 func (t *TextDisplay) DrawCard(x, y int, card Card) {
-	cardArt := getCardArt(card)
+	t.drawArt(x, y, getCardArt(card), t.cardStyle(card))
+}
+
+// selectionStyle returns the colors a card being selected from is drawn in
+func (t *TextDisplay) selectionStyle(index int) tcell.Style {
+	if !t.selectable[index] {
+		return tcell.StyleDefault.Foreground(tcell.ColorGray).Dim(true)
+	}
+	style := t.cardStyle(t.choices[index])
+	if index == t.selected {
+		return style.Reverse(true)
+	}
+	return style
+}
+
+// DrawSelectableHand draws the cards being selected from, with the highlighted
+// card reversed and the ones that can't be picked greyed out
+func (t *TextDisplay) DrawSelectableHand(x, y int) {
+	t.cardBounds = make([]cardBounds, len(t.choices))
+	for i, card := range t.choices {
+		cardX := x + i*12
+		t.drawArt(cardX, y, getCardArt(card), t.selectionStyle(i))
+		t.cardBounds[i] = cardBounds{x: cardX, y: y, width: 11, height: 9}
+		t.DrawText(cardX+4, y+9, fmt.Sprintf("(%d)", i))
+	}
+}
 
-	colorWay := color.New(color.FgWhite).SprintFunc()
+// cardStyle returns the colors a card is drawn in. Once trump is picked the left
+// bauer is drawn in the colors of trump, the suite it counts as.
+func (t *TextDisplay) cardStyle(card Card) tcell.Style {
+	trump := NONE
+	if t.view != nil {
+		trump = t.view.Trump
+	}
+	return suiteStyle(EffectiveSuit(card, trump))
+}
 
-	switch card.suite {
+// suiteStyle returns the colors cards of a suite are drawn in
+func suiteStyle(s Suite) tcell.Style {
+	style := tcell.StyleDefault
+	switch s {
 	case HEART:
-		colorWay = color.New(color.FgRed).SprintFunc()
+		return style.Foreground(tcell.ColorRed)
 	case DIAMOND:
-		colorWay = color.New(color.FgMagenta).SprintFunc()
+		return style.Foreground(tcell.ColorFuchsia)
 	case CLUB:
-		colorWay = color.New(color.FgYellow).SprintFunc()
+		return style.Foreground(tcell.ColorYellow)
 	case SPADE:
-		colorWay = color.New(color.FgGreen).SprintFunc()
+		return style.Foreground(tcell.ColorGreen)
 	}
+	return style.Foreground(tcell.ColorWhite)
+}
 
-	for i, row := range cardArt {
+func (t *TextDisplay) drawArt(x, y int, art [][]rune, style tcell.Style) {
+	for i, row := range art {
 		for j, cell := range row {
-			t.grid[y+i][x+j] = colorWay(string(cell))
+			t.screen.SetContent(x+j, y+i, cell, nil, style)
 		}
 	}
 }
@@ -137,15 +450,32 @@ func getCardArt(c Card) [][]rune {
 }
 
 func (t *TextDisplay) DrawText(x, y int, text string) {
-	for i, c := range text {
-		t.grid[y][x+i] = string(c)
+	for i, c := range []rune(text) {
+		t.DrawRune(x+i, y, c)
+	}
+}
+
+func (t *TextDisplay) DrawCardBack(x, y int) {
+	t.drawArt(x, y, getCardBackArt(), tcell.StyleDefault.Foreground(tcell.ColorBlue))
+}
+
+func getCardBackArt() [][]rune {
+	return [][]rune{
+		[]rune("┌─────────┐"),
+		[]rune("│░░░░░░░░░│"),
+		[]rune("│░░░░░░░░░│"),
+		[]rune("│░░░░░░░░░│"),
+		[]rune("│░░░░░░░░░│"),
+		[]rune("│░░░░░░░░░│"),
+		[]rune("│░░░░░░░░░│"),
+		[]rune("│░░░░░░░░░│"),
+		[]rune("└─────────┘"),
 	}
 }
 
-func (t *TextDisplay) DrawPlayerHand(x, y int, player Player, enumerate bool) {
-	cards := player.hand
+func (t *TextDisplay) DrawPlayerHand(x, y int, cards []Card, enumerate bool) {
 	for i, card := range cards {
-		t.DrawCard(x+i*12, y, *card)
+		t.DrawCard(x+i*12, y, card)
 	}
 
 	// draw the index of the card beneath each card
@@ -156,142 +486,117 @@ func (t *TextDisplay) DrawPlayerHand(x, y int, player Player, enumerate bool) {
 	}
 }
 
-func (t *TextDisplay) DrawPlayerHands(game *Game) {
-	player1 := *game.Players[0]
-	player2 := *game.Players[1]
-	player3 := *game.Players[2]
-	player4 := *game.Players[3]
-
-	t.DrawText(3, 2, player1.name)
-	t.DrawPlayerHand(2, 3, player1, true)
-
-	t.DrawText(3, 14, player2.name)
-	t.DrawPlayerHand(2, 15, player2, true)
-
-	t.DrawText(3, 26, player3.name)
-	t.DrawPlayerHand(2, 27, player3, true)
-
-	t.DrawText(3, 38, player4.name)
-	t.DrawPlayerHand(2, 39, player4, true)
+// DrawHiddenHand draws the backs of the cards in a hand the viewer can't see
+func (t *TextDisplay) DrawHiddenHand(x, y int, count int) {
+	for i := 0; i < count; i++ {
+		t.DrawCardBack(x+i*12, y)
+	}
 }
 
-func (t *TextDisplay) DrawDealerArrow(game *Game) {
-	dealerIndex := game.DealerIndex
+func (t *TextDisplay) DrawPlayerHands(view PlayerView) {
+	for i, name := range view.Names {
+		y := 2 + 12*i
+		t.DrawText(3, y, name)
+		if t.selecting && i == t.selectSeat {
+			t.DrawSelectableHand(2, y+1)
+		} else if i == view.Seat {
+			t.DrawPlayerHand(2, y+1, view.Hand, true)
+		} else {
+			t.DrawHiddenHand(2, y+1, view.HandSizes[i])
+		}
+	}
+}
 
-	y := 5 + 12*dealerIndex
+func (t *TextDisplay) DrawDealerArrow(view PlayerView) {
+	y := 5 + 12*view.DealerIndex
 	x := 61
 	t.DrawText(x, y, "<-- Dealer")
 }
 
-func (t *TextDisplay) DrawTurnArrow(game *Game) {
-	playerIndex := game.PlayerIndex
-
-	y := 6 + 12*playerIndex
+func (t *TextDisplay) DrawTurnArrow(view PlayerView) {
+	y := 6 + 12*view.PlayerIndex
 	x := 61
 	t.DrawText(x, y, "<-- Turn")
 }
 
-func (t *TextDisplay) DrawPlayedCards(game *Game) {
-	t.DrawText(80, 2, "Played Cards")
-	cards := game.PlayedCards
+func (t *TextDisplay) DrawPlayedCards(x int, view PlayerView) {
+	t.DrawText(x, 2, "Played Cards")
+	cards := view.PlayedCards
 
-	if game.StateMachine.CurrentState.GetName() == DrawForDealer {
+	if view.State == DrawForDealer {
 		if len(cards) > 0 {
 			lastIndex := len(cards) - 1
-			t.DrawCard(80, 5, *cards[lastIndex])
+			t.DrawCard(x, 5, cards[lastIndex])
 		}
 	} else {
 		for i, card := range cards {
-			t.DrawCard(80, 5+(10*i), *card)
+			t.DrawCard(x, 5+(10*i), card)
 		}
 	}
 }
 
-func (t *TextDisplay) DrawTurnedCard(game *Game) {
-	t.DrawText(100, 2, "Turned Card")
-	card := game.TurnedCard
+func (t *TextDisplay) DrawTurnedCard(x int, view PlayerView) {
+	t.DrawText(x, 2, "Turned Card")
+	card := view.TurnedCard
 	if card == nil {
 		return
 	}
-	t.DrawCard(100, 5, *card)
+	t.DrawCard(x, 5, *card)
 }
 
-func (t *TextDisplay) DrawLogs(game *Game) {
-	for i, log := range game.logs {
-		t.DrawText(120, 2+i, log)
-	}
-}
-
-func (t *TextDisplay) DrawStats(game *Game) {
-	t.DrawText(120, 2, "Stats")
-	t.DrawText(120, 3, "-----")
-	t.DrawText(120, 4, fmt.Sprintf("Trump:          %s", game.Trump.ToString()))
+func (t *TextDisplay) DrawStats(x int, view PlayerView) {
+	t.DrawText(x, 2, "Stats")
+	t.DrawText(x, 3, "-----")
+	t.DrawText(x, 4, fmt.Sprintf("Trump:          %s", view.Trump.ToString()))
 	orderedPlayer := ""
-	if game.OrderedPlayerIndex != -1 {
-		orderedPlayer = game.Players[game.OrderedPlayerIndex].name
+	if view.OrderedPlayerIndex != -1 {
+		orderedPlayer = view.Names[view.OrderedPlayerIndex]
 	}
-	t.DrawText(120, 5, fmt.Sprintf("Ordered Up:     %s", orderedPlayer))
-	t.DrawText(120, 6, fmt.Sprintf("Dealer:         %s", game.Players[game.DealerIndex].name))
-	t.DrawText(120, 7, fmt.Sprintf("Turn:           %s", game.Players[game.PlayerIndex].name))
+	t.DrawText(x, 5, fmt.Sprintf("Ordered Up:     %s", orderedPlayer))
+	t.DrawText(x, 6, fmt.Sprintf("Dealer:         %s", view.Names[view.DealerIndex]))
+	t.DrawText(x, 7, fmt.Sprintf("Turn:           %s", view.Names[view.PlayerIndex]))
 	turnedCardString := ""
-	if game.TurnedCard != nil {
-		turnedCardString = game.TurnedCard.ToString()
-	}
-	t.DrawText(120, 8, fmt.Sprintf("Turned Card:    %s", turnedCardString))
-	t.DrawText(120, 9, fmt.Sprintf("Played Cards:   %d", len(game.PlayedCards)))
-	t.DrawText(120, 10, fmt.Sprintf("State:          %s", game.StateMachine.CurrentState.GetName()))
-	t.DrawText(120, 11, fmt.Sprintf("Cards in Deck:  %d", len(game.Deck.cards)))
-	t.DrawText(120, 12, fmt.Sprintf("Team 1 Tricks:  %d", game.Players[0].tricksTaken+game.Players[2].tricksTaken))
-	t.DrawText(120, 13, fmt.Sprintf("Team 2 Tricks:  %d", game.Players[1].tricksTaken+game.Players[3].tricksTaken))
-	t.DrawText(120, 14, fmt.Sprintf("Team 1 Points:  %d", game.Players[0].pointsEarned))
-	t.DrawText(120, 15, fmt.Sprintf("Team 2 Points:  %d", game.Players[1].pointsEarned))
-}
-
-func (t *TextDisplay) DrawBounds() {
-	t.DrawVerticalLine(0, 0, 50)
-	t.DrawHorizontalLine(0, 0, 165)
-	t.DrawVerticalLine(75, 1, 50)
-	t.DrawVerticalLine(95, 1, 50)
-	t.DrawVerticalLine(115, 1, 50)
-	t.DrawVerticalLine(165, 1, 50)
-	t.DrawHorizontalLine(0, 50, 165)
-	t.DrawRune(0, 0, '┌')
-	t.DrawRune(0, 50, '└')
-	t.DrawRune(165, 0, '┐')
-	t.DrawRune(165, 50, '┘')
-}
-
-func (t *TextDisplay) DrawBoard(game *Game) {
-	t.ClearDisplay()
-	if game.StateMachine.CurrentState.GetName() == InitGame {
-		return
+	if view.TurnedCard != nil {
+		turnedCardString = view.TurnedCard.ToString()
 	}
-	t.DrawBounds()
-	t.DrawPlayerHands(game)
-	t.DrawDealerArrow(game)
-	t.DrawTurnArrow(game)
-	t.DrawPlayedCards(game)
-	t.DrawTurnedCard(game)
-	t.DrawStats(game)
-	t.Render()
-}
-
-func ClearTerminal() {
-	// Clear command based on the operating system
-	var cmd *exec.Cmd
-	if runtime.GOOS == "windows" {
-		cmd = exec.Command("cmd", "/c", "cls")
-	} else {
-		cmd = exec.Command("clear")
+	t.DrawText(x, 8, fmt.Sprintf("Turned Card:    %s", turnedCardString))
+	t.DrawText(x, 9, fmt.Sprintf("Played Cards:   %d", len(view.PlayedCards)))
+	t.DrawText(x, 10, fmt.Sprintf("State:          %s", view.State))
+	t.DrawText(x, 11, fmt.Sprintf("Cards in Deck:  %d", view.DeckSize))
+	y := 12
+	for i, name := range view.TeamNames {
+		t.DrawText(x, y, fmt.Sprintf("%-15s %d", name+" Tricks:", view.Tricks[i]))
+		t.DrawText(x, y+len(view.TeamNames), fmt.Sprintf("%-15s %d", name+" Points:", view.Points[i]))
+		y++
 	}
+	y += len(view.TeamNames)
 
-	// Set the correct output device for the command
-	cmd.Stdout = os.Stdout
-
-	// Run the command to clear the screen
-	cmd.Run()
+	// nobody can go alone when every player is a team, as in cutthroat
+	if len(view.TeamNames) < len(view.Names) {
+		alonePlayer := ""
+		if view.AlonePlayerIndex != -1 {
+			alonePlayer = view.Names[view.AlonePlayerIndex]
+		}
+		t.DrawText(x, y, fmt.Sprintf("Going Alone:    %s", alonePlayer))
+		y++
+	}
+	t.DrawText(x, y, fmt.Sprintf("Playing To:     %d", view.TargetScore))
 }
 
-func moveCursorHome() {
-	fmt.Print("\033[H")
+// DrawBounds draws the border around the full board and the lines between its
+// columns. The stats column stretches to the right side of the terminal.
+func (t *TextDisplay) DrawBounds(width, height int, board fullBoard) {
+	right := width - 1
+	bottom := height - 1
+	t.DrawVerticalLine(0, 0, bottom)
+	t.DrawHorizontalLine(0, 0, right)
+	t.DrawVerticalLine(board.played, 1, bottom)
+	t.DrawVerticalLine(board.turned, 1, bottom)
+	t.DrawVerticalLine(board.stats, 1, bottom)
+	t.DrawVerticalLine(right, 1, bottom)
+	t.DrawHorizontalLine(0, bottom, right)
+	t.DrawRune(0, 0, '┌')
+	t.DrawRune(0, bottom, '└')
+	t.DrawRune(right, 0, '┐')
+	t.DrawRune(right, bottom, '┘')
 }
@@ -0,0 +1,321 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestDisplay(t *testing.T, width, height int) (*TextDisplay, tcell.SimulationScreen) {
+	screen := tcell.NewSimulationScreen("")
+	display, err := newTextDisplay(screen)
+	assert.NoError(t, err)
+	screen.SetSize(width, height)
+	return display, screen
+}
+
+// screenText returns the characters on a row of the screen
+func screenText(screen tcell.SimulationScreen, y int) string {
+	cells, width, _ := screen.GetContents()
+	var builder strings.Builder
+	for x := 0; x < width; x++ {
+		runes := cells[y*width+x].Runes
+		if len(runes) == 0 {
+			builder.WriteRune(' ')
+		} else {
+			builder.WriteRune(runes[0])
+		}
+	}
+	return builder.String()
+}
+
+func testView() PlayerView {
+	view := PlayerView{}
+	view.Seat = 0
+	view.State = TrumpSelectionOne
+	view.Names = []string{"Player 1", "Player 2", "Player 3", "Player 4"}
+	view.Hand = []Card{{JACK, HEART}, {ACE, SPADE}}
+	view.HandSizes = []int{2, 2, 2, 2}
+	view.TeamNames = []string{"Team One", "Team Two"}
+	view.Tricks = []int{0, 0}
+	view.Points = []int{0, 0}
+	view.TurnedCard = &Card{NINE, CLUB}
+	view.OrderedPlayerIndex = -1
+	view.AlonePlayerIndex = -1
+	view.TargetScore = 10
+	return view
+}
+
+func TestDisplayDrawsBoard(t *testing.T) {
+	display, screen := newTestDisplay(t, 166, 60)
+	defer display.Close()
+
+	display.DrawBoard(testView())
+	assert.Contains(t, screenText(screen, 2), "Player 1")
+	assert.Contains(t, screenText(screen, 4), "J", "expected the player's own cards face up")
+	assert.Contains(t, screenText(screen, 16), "░░░", "expected other hands face down")
+	assert.Contains(t, screenText(screen, 8), "Turned Card:    9 of Clubs")
+}
+
+func TestDisplayCompactBoard(t *testing.T) {
+	display, screen := newTestDisplay(t, 80, 24)
+	defer display.Close()
+
+	view := testView()
+	view.Seat = 2
+	view.Hand = []Card{{TEN, DIAMOND}, {KING, CLUB}}
+	view.Trick = []Play{{Seat: 1, Card: Card{QUEEN, SPADE}}}
+	display.DrawBoard(view)
+
+	// the viewer sits at the bottom with the other seats around the table
+	assert.Contains(t, screenText(screen, 19), "Player 3")
+	assert.Contains(t, screenText(screen, 20), "10♦  K♣")
+	assert.Contains(t, screenText(screen, 2), "▶ Player 1 (D)")
+	assert.Contains(t, screenText(screen, 3), "░░░ ░░░")
+	assert.Contains(t, screenText(screen, 10), "Player 4")
+	assert.Contains(t, screenText(screen, 10), "Player 2")
+	assert.Contains(t, screenText(screen, 11), "9♣", "expected the turned card in the middle")
+	assert.Contains(t, screenText(screen, 11), "Q♠", "expected the trick in front of who played it")
+	assert.Contains(t, screenText(screen, 1), "Playing To: 10")
+}
+
+func TestDisplayCutthroatBoard(t *testing.T) {
+	display, screen := newTestDisplay(t, 80, 24)
+	defer display.Close()
+
+	view := testView()
+	view.Seat = 1
+	view.Names = []string{"Alice", "Bob", "Carol"}
+	view.HandSizes = []int{2, 2, 2}
+	view.TeamNames = view.Names
+	view.Tricks = []int{0, 1, 0}
+	view.Points = []int{3, 0, 4}
+	display.DrawBoard(view)
+
+	assert.Contains(t, screenText(screen, 19), "Bob")
+	assert.Contains(t, screenText(screen, 10), "Carol", "expected the next seat on the left")
+	assert.Contains(t, screenText(screen, 2), "Alice", "expected the last seat across the table")
+	assert.Contains(t, screenText(screen, 1), "Carol: 4 points, 0 tricks")
+
+	display, screen = newTestDisplay(t, 166, 60)
+	defer display.Close()
+	display.DrawBoard(view)
+	assert.Contains(t, screenText(screen, 13), "Bob Tricks:     1")
+	assert.Contains(t, screenText(screen, 17), "Carol Points:   4")
+	assert.Contains(t, screenText(screen, 18), "Playing To:     10")
+}
+
+func TestDisplayFullBoardFitsTerminal(t *testing.T) {
+	// the narrowest full board for four players, plus the message and input lines
+	display, screen := newTestDisplay(t, fullBoardWidth(), fullBoardHeight(4)+2)
+	defer display.Close()
+	display.DrawBoard(testView())
+	assert.Equal(t, '│', []rune(screenText(screen, 1))[handsColumnWidth], "expected the full board")
+	assert.Contains(t, screenText(screen, 8), "Turned Card:    9 of Clubs")
+
+	// a cutthroat table needs fewer lines
+	view := testView()
+	view.Names = []string{"Alice", "Bob", "Carol"}
+	view.HandSizes = []int{2, 2, 2}
+	view.TeamNames = view.Names
+	view.Tricks = []int{0, 0, 0}
+	view.Points = []int{0, 0, 0}
+	screen.SetSize(fullBoardWidth(), fullBoardHeight(3)+2)
+	display.DrawBoard(view)
+	assert.Equal(t, '│', []rune(screenText(screen, 1))[handsColumnWidth], "expected the full board")
+
+	// extra width is shared between the columns right of the hands
+	screen.SetSize(fullBoardWidth()+30, 60)
+	display.DrawBoard(testView())
+	row := []rune(screenText(screen, 1))
+	assert.Equal(t, '│', row[handsColumnWidth+cardColumnWidth+10])
+	assert.Equal(t, '│', row[handsColumnWidth+2*cardColumnWidth+20])
+
+	// one line short and it's the compact board
+	screen.SetSize(fullBoardWidth(), fullBoardHeight(4)+1)
+	display.DrawBoard(testView())
+	assert.Contains(t, screenText(screen, 0), "Trump")
+}
+
+func TestDisplayTinyTerminalDoesNotPanic(t *testing.T) {
+	display, screen := newTestDisplay(t, 20, 5)
+	defer display.Close()
+
+	display.DrawBoard(testView())
+	assert.Contains(t, screenText(screen, 0), "Trump")
+}
+
+func TestDisplayRedrawsOnResize(t *testing.T) {
+	display, screen := newTestDisplay(t, 166, 60)
+	defer display.Close()
+
+	display.DrawBoard(testView())
+	assert.Contains(t, screenText(screen, 8), "Turned Card:")
+
+	screen.SetSize(80, 24)
+	screen.PostEvent(tcell.NewEventResize(80, 24))
+	assert.Eventually(t, func() bool {
+		return strings.Contains(screenText(screen, 1), "Playing To: 10")
+	}, time.Second, time.Millisecond)
+}
+
+func TestDisplayReadsInputLine(t *testing.T) {
+	display, screen := newTestDisplay(t, 80, 24)
+	defer display.Close()
+
+	answers := make(chan string)
+	go func() {
+		answers <- display.ReadLine("Pick a card: ")
+	}()
+
+	// wait for the prompt to be drawn before typing
+	assert.Eventually(t, func() bool {
+		return strings.Contains(screenText(screen, 23), "Pick a card:")
+	}, time.Second, time.Millisecond)
+	screen.InjectKeyBytes([]byte("12"))
+	screen.InjectKey(tcell.KeyBackspace2, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+
+	select {
+	case answer := <-answers:
+		assert.Equal(t, "1", answer)
+	case <-time.After(time.Second):
+		t.Fatal("expected ReadLine to return after Enter")
+	}
+}
+
+func TestDisplayCtrlCInterrupts(t *testing.T) {
+	display, screen := newTestDisplay(t, 80, 24)
+	defer display.Close()
+
+	screen.InjectKey(tcell.KeyCtrlC, 0, tcell.ModCtrl)
+	select {
+	case <-display.Interrupted():
+	case <-time.After(time.Second):
+		t.Fatal("expected Ctrl-C to interrupt")
+	}
+}
+
+func TestDisplaySelectCardSkipsUnplayableCards(t *testing.T) {
+	display, screen := newTestDisplay(t, 166, 60)
+	defer display.Close()
+	display.DrawBoard(testView())
+
+	cards := []Card{{NINE, HEART}, {TEN, SPADE}, {JACK, HEART}, {ACE, HEART}}
+	selectable := []bool{false, true, false, true}
+	answers := make(chan int)
+	go func() {
+		index, _ := display.SelectCard(0, cards, selectable, "Pick a card: ")
+		answers <- index
+	}()
+
+	assert.Eventually(t, func() bool {
+		return strings.Contains(screenText(screen, 59), "Pick a card:")
+	}, time.Second, time.Millisecond)
+	// the highlight starts on the first playable card, and moving right skips
+	// the unplayable jack
+	screen.InjectKey(tcell.KeyRight, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	assert.Equal(t, 3, <-answers)
+}
+
+func TestDisplaySelectCardWithMouse(t *testing.T) {
+	display, screen := newTestDisplay(t, 166, 60)
+	defer display.Close()
+	display.DrawBoard(testView())
+
+	cards := []Card{{NINE, HEART}, {TEN, SPADE}, {JACK, HEART}}
+	selectable := []bool{true, false, true}
+	answers := make(chan int)
+	go func() {
+		index, _ := display.SelectCard(1, cards, selectable, "Pick a card: ")
+		answers <- index
+	}()
+
+	assert.Eventually(t, func() bool {
+		return strings.Contains(screenText(screen, 59), "Pick a card:")
+	}, time.Second, time.Millisecond)
+	// the second seat's hand starts on row 15. Clicking the unplayable card does
+	// nothing
+	screen.InjectMouse(2+12+5, 18, tcell.Button1, tcell.ModNone)
+	screen.InjectMouse(2+24+5, 18, tcell.Button1, tcell.ModNone)
+	assert.Equal(t, 2, <-answers)
+}
+
+func TestPickCardRejectsUnplayableIndex(t *testing.T) {
+	defer func(original func(*Player, []bool, string) (int, string)) { selectCard = original }(selectCard)
+
+	player := InitPlayer("Player 1", 0)
+	player.GiveCards([]*Card{{NINE, HEART}, {TEN, SPADE}})
+	answers := []string{"0", "5", "x", "1"}
+	prompts := make([]string, 0)
+	selectCard = func(player *Player, selectable []bool, prompt string) (int, string) {
+		prompts = append(prompts, prompt)
+		answer := answers[0]
+		answers = answers[1:]
+		return -1, answer
+	}
+
+	card := pickCard(player, []*Card{player.hand[1]}, "Pick a card: ")
+	assert.Equal(t, player.hand[1], card)
+	assert.Equal(t, "Pick a card: ", prompts[0])
+	assert.Equal(t, "Received invalid input! Pick a card: ", prompts[1])
+}
+
+func TestDisplayHistoryPanelScrolls(t *testing.T) {
+	display, screen := newTestDisplay(t, 80, 24)
+	defer display.Close()
+
+	view := testView()
+	view.Bidding = []Event{{Type: PassEvent, Player: 1}, {Type: OrderUpEvent, Player: 2}}
+	view.TrickHistory = []TrickRecord{{Plays: []Play{{Seat: 1, Card: Card{ACE, SPADE}}, {Seat: 2, Card: Card{NINE, SPADE}}}, Winner: 1}}
+	display.DrawBoard(view)
+	for i := 0; i < 40; i++ {
+		display.AddLogs(fmt.Sprintf("log line %d", i))
+	}
+
+	screen.InjectKey(tcell.KeyF2, 0, tcell.ModNone)
+	waitForText(t, screen, 21, "log line 39")
+
+	// scrolling back to the top shows the bidding and tricks
+	for i := 0; i < 5; i++ {
+		screen.InjectKey(tcell.KeyPgUp, 0, tcell.ModNone)
+	}
+	waitForText(t, screen, 1, "Bidding")
+	assert.Contains(t, screenText(screen, 3), "Player 2 passed")
+	assert.Contains(t, screenText(screen, 4), "Player 3 ordered it up")
+	assert.Contains(t, screenText(screen, 8), "1. Player 2 led: Player 2 A♠, Player 3 9♠")
+	assert.Contains(t, screenText(screen, 9), "Won by Player 2")
+
+	// scrolling down a page from the top puts the log at the top of the panel
+	screen.InjectKey(tcell.KeyPgDn, 0, tcell.ModNone)
+	waitForText(t, screen, 1, "Log")
+}
+
+func TestDisplayFullBoardShowsHistoryPanel(t *testing.T) {
+	display, screen := newTestDisplay(t, 166, 60)
+	defer display.Close()
+
+	display.DrawBoard(testView())
+	display.AddLogs("Player 1 was dealt 5 cards")
+	waitForText(t, screen, 20, "History")
+	assert.Contains(t, screenText(screen, 29), "Player 1 was dealt 5 cards")
+}
+
+func TestDisplayDrawsLeftBauerAsTrump(t *testing.T) {
+	display, _ := newTestDisplay(t, 166, 60)
+	defer display.Close()
+
+	view := testView()
+	display.DrawBoard(view)
+	assert.Equal(t, suiteStyle(DIAMOND), display.cardStyle(Card{JACK, DIAMOND}))
+
+	view.Trump = HEART
+	display.DrawBoard(view)
+	assert.Equal(t, suiteStyle(HEART), display.cardStyle(Card{JACK, DIAMOND}))
+	assert.Equal(t, suiteStyle(DIAMOND), display.cardStyle(Card{ACE, DIAMOND}))
+}
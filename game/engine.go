@@ -0,0 +1,58 @@
+package game
+
+import "fmt"
+
+// Logger receives the messages a game logs. A *log.Logger is a Logger.
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+// Observer is told about a game as it's played. It's called on the goroutine
+// stepping the game.
+type Observer interface {
+	OnEvent(event Event)     // an event was emitted
+	OnState(state StateName) // the game moved to a new state
+}
+
+// EngineConfig holds the options for a game run by NewEngine
+type EngineConfig struct {
+	Rules       RuleSet
+	Seed        int64              // master seed for the shuffles. One is picked from the time if 0
+	Controllers []PlayerController // one for every seat, see RuleSet.PlayerCount
+	Names       []string           // names of the players. A default is used for any missing or left empty
+	Logger      Logger             // receives the game's log messages, if set
+	Observer    Observer           // told about every event and state change, if set
+}
+
+// NewEngine creates a game played entirely by the given controllers. It doesn't
+// touch the terminal, stdin or the filesystem unless a controller, the logger or
+// the observer does, so many can be run at once.
+func NewEngine(config EngineConfig) (*Game, error) {
+	game := NewGame(config.Rules)
+	if config.Seed != 0 {
+		game.RandSeed = config.Seed
+	}
+	game.Logger = config.Logger
+	game.StateMachine.Observer = config.Observer
+
+	if len(config.Controllers) != len(game.Players) {
+		return nil, fmt.Errorf("%d controllers for %d players", len(config.Controllers), len(game.Players))
+	}
+	for i, player := range game.Players {
+		if config.Controllers[i] == nil {
+			return nil, fmt.Errorf("player %d has no controller", i+1)
+		}
+		player.SetController(config.Controllers[i])
+		if i < len(config.Names) && config.Names[i] != "" {
+			player.name = config.Names[i]
+		}
+	}
+	return &game, nil
+}
+
+// PlayToEnd steps the game until it's over
+func (g *Game) PlayToEnd() {
+	for g.StateMachine.CurrentState.GetName() != EndGame {
+		g.StateMachine.Step(g)
+	}
+}
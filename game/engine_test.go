@@ -0,0 +1,120 @@
+package game
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingLogger struct {
+	lines []string
+}
+
+func (l *recordingLogger) Printf(format string, args ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, args...))
+}
+
+type recordingObserver struct {
+	events []Event
+	states []StateName
+}
+
+func (o *recordingObserver) OnEvent(event Event) {
+	o.events = append(o.events, event)
+}
+
+func (o *recordingObserver) OnState(state StateName) {
+	o.states = append(o.states, state)
+}
+
+func botEngineConfig(seed int64) EngineConfig {
+	config := EngineConfig{}
+	config.Rules = DefaultRuleSet()
+	config.Seed = seed
+	config.Controllers = make([]PlayerController, config.Rules.PlayerCount())
+	for i := range config.Controllers {
+		config.Controllers[i] = NewRuleBotController()
+	}
+	return config
+}
+
+func cutthroatEngineConfig(seed int64) EngineConfig {
+	config := botEngineConfig(seed)
+	config.Rules.Cutthroat = true
+	config.Controllers = config.Controllers[:config.Rules.PlayerCount()]
+	return config
+}
+
+func TestEnginePlaysGameWithoutSideEffects(t *testing.T) {
+	_, err := os.Stat("log.out")
+	hadLogFile := err == nil
+
+	logger := recordingLogger{}
+	observer := recordingObserver{}
+	config := botEngineConfig(1)
+	config.Names = []string{"Alice", "", "Carol"}
+	config.Logger = &logger
+	config.Observer = &observer
+
+	game, err := NewEngine(config)
+	assert.NoError(t, err)
+	game.PlayToEnd()
+
+	assert.Equal(t, EndGame, game.StateMachine.CurrentState.GetName())
+	assert.Equal(t, "Alice", game.Players[0].name)
+	assert.Equal(t, "Player 2", game.Players[1].name)
+	assert.Equal(t, "Player 4", game.Players[3].name)
+	assert.Equal(t, game.logs, logger.lines)
+	assert.Equal(t, game.StateMachine.Events, observer.events)
+	assert.Equal(t, EndGame, observer.states[len(observer.states)-1])
+
+	if !hadLogFile {
+		_, err = os.Stat("log.out")
+		assert.True(t, os.IsNotExist(err), "expected the engine not to write log.out")
+	}
+}
+
+func TestEngineGamesRunInParallel(t *testing.T) {
+	play := func(seed int64) []Event {
+		game, err := NewEngine(botEngineConfig(seed))
+		assert.NoError(t, err)
+		game.PlayToEnd()
+		return game.StateMachine.Events
+	}
+	expected := map[int64][]Event{1: play(1), 2: play(2)}
+
+	// games running at the same time don't share anything, so each is played
+	// the same way it is on its own
+	type result struct {
+		seed   int64
+		events []Event
+	}
+	results := make(chan result, 8)
+	for i := 0; i < cap(results); i++ {
+		go func(seed int64) {
+			results <- result{seed, play(seed)}
+		}(int64(i%2 + 1))
+	}
+	for i := 0; i < cap(results); i++ {
+		r := <-results
+		assert.Equal(t, expected[r.seed], r.events)
+	}
+}
+
+func TestEngineNeedsEveryController(t *testing.T) {
+	config := botEngineConfig(1)
+	config.Controllers[2] = nil
+	_, err := NewEngine(config)
+	assert.Error(t, err)
+
+	// a cutthroat game has a seat fewer
+	config = botEngineConfig(1)
+	config.Rules.Cutthroat = true
+	_, err = NewEngine(config)
+	assert.Error(t, err)
+
+	_, err = NewEngine(cutthroatEngineConfig(1))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,278 @@
+package game
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+type EventType string
+
+const (
+	ShuffleEvent   EventType = "Shuffle"   // the deck was shuffled with Seed. Cards holds the new order, top card last
+	DealerEvent    EventType = "Dealer"    // Player drew a jack and is the first dealer
+	DealEvent      EventType = "Deal"      // Player was dealt Cards
+	TurnCardEvent  EventType = "TurnCard"  // Cards[0] was turned up
+	OrderUpEvent   EventType = "OrderUp"   // Player ordered up the turned card
+	PassEvent      EventType = "Pass"      // Player passed on trump
+	PickSuiteEvent EventType = "PickSuite" // Player named Suite as trump
+	LonerEvent     EventType = "Loner"     // Player made trump and decided if they are going Alone
+	DiscardEvent   EventType = "Discard"   // Player picked up the turned card and discarded Cards[0]
+	PlayCardEvent  EventType = "PlayCard"  // Player played Cards[0]
+	TrickWonEvent  EventType = "TrickWon"  // Player won the trick with Cards[0]
+	PointsEvent    EventType = "Points"    // Team earned Points
+)
+
+// Event records a player decision or random outcome. Together the events of a
+// game are enough to rebuild it with Replay.
+type Event struct {
+	Type   EventType `json:"type"`
+	Player int       `json:"player"`
+	Cards  []Card    `json:"cards,omitempty"`
+	Suite  Suite     `json:"suite,omitempty"`
+	Alone  bool      `json:"alone,omitempty"`
+	Team   int       `json:"team,omitempty"`
+	Points int       `json:"points,omitempty"`
+	Seed   int64     `json:"seed,omitempty"`
+}
+
+// EventLog is an append-only log of events written as one JSON object per line
+type EventLog struct {
+	encoder *json.Encoder
+	err     error
+}
+
+func NewEventLog(w io.Writer) *EventLog {
+	log := EventLog{}
+	log.encoder = json.NewEncoder(w)
+	return &log
+}
+
+// Append writes the event to the end of the log. Once a write fails the log
+// stops writing and the error is kept in Err.
+func (l *EventLog) Append(event Event) error {
+	if l.err != nil {
+		return l.err
+	}
+	l.err = l.encoder.Encode(event)
+	return l.err
+}
+
+// Err returns the first error the log ran into
+func (l *EventLog) Err() error {
+	return l.err
+}
+
+// ReadEvents reads every event from a log written by EventLog
+func ReadEvents(r io.Reader) ([]Event, error) {
+	events := make([]Event, 0)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		events = append(events, event)
+	}
+	return events, scanner.Err()
+}
+
+// Replay rebuilds a game by feeding the events back through StateMachine.Step.
+// Every seat is played by a ReplayController and shuffles put the deck back in
+// the recorded order. The game is returned once every event has been replayed,
+// so it can be continued by giving the players new controllers.
+func Replay(events []Event, rules RuleSet) (*Game, error) {
+	g := NewGame(rules)
+	game := &g
+	for _, player := range game.Players {
+		player.SetController(NewReplayController())
+	}
+	game.StateMachine.replay = events
+
+	for len(game.StateMachine.Events) < len(events) {
+		if game.StateMachine.CurrentState.GetName() == EndGame {
+			return game, fmt.Errorf("game ended after %d of %d events", len(game.StateMachine.Events), len(events))
+		}
+
+		before := len(game.StateMachine.Events)
+		from := game.StateMachine.CurrentState.GetName()
+		game.StateMachine.Step(game)
+		if err := game.StateMachine.replayErr; err != nil {
+			return game, fmt.Errorf("replay failed at event %d: %w", before, err)
+		}
+		if len(game.StateMachine.Events) == before && askedAgain(from, game.StateMachine.CurrentState.GetName()) {
+			return game, fmt.Errorf("replay diverged from the log at event %d: the recorded decision was rejected", before)
+		}
+		for i := before; i < len(game.StateMachine.Events); i++ {
+			if i >= len(events) || !reflect.DeepEqual(game.StateMachine.Events[i], events[i]) {
+				return game, fmt.Errorf("replay diverged from the log at event %d", i)
+			}
+		}
+	}
+
+	game.StateMachine.replay = nil
+	return game, nil
+}
+
+// askedAgain reports whether a step from a decision state went back to ask the
+// player again, which only happens when their decision wasn't allowed
+func askedAgain(from StateName, to StateName) bool {
+	switch from {
+	case DealerPickupTrump, TrumpSelectionTwo, ScrewDealer:
+		return to == from
+	case CheckValidCard:
+		return to == GetPlayerCard
+	}
+	return false
+}
+
+// ReplayController makes the decisions that were recorded in the event log
+// being replayed. If a recorded decision isn't allowed it records the error on
+// the state machine, so a bad log fails instead of being asked for the decision
+// again, and answers with something the state rejects where it can.
+type ReplayController struct{}
+
+func NewReplayController() *ReplayController {
+	return &ReplayController{}
+}
+
+func (rc *ReplayController) OrderUp(player *Player, game *Game) bool {
+	event, ok := game.StateMachine.nextReplayEvent(player, OrderUpEvent, PassEvent)
+	return ok && event.Type == OrderUpEvent
+}
+
+func (rc *ReplayController) PickSuite(player *Player, game *Game, mustPick bool) Suite {
+	event, ok := game.StateMachine.nextReplayEvent(player, PickSuiteEvent, PassEvent)
+	if !ok {
+		return game.TurnedCard.suite
+	}
+	if event.Type == PassEvent {
+		if mustPick {
+			game.StateMachine.failReplay("%s passed but had to pick a suite", player.name)
+		}
+		return NONE
+	}
+	if event.Suite == NONE || event.Suite == game.TurnedCard.suite {
+		game.StateMachine.failReplay("%s picked %s which can't be trump", player.name, event.Suite.ToString())
+		return game.TurnedCard.suite
+	}
+	return event.Suite
+}
+
+func (rc *ReplayController) GoAlone(player *Player, game *Game) bool {
+	event, ok := game.StateMachine.nextReplayEvent(player, LonerEvent)
+	return ok && event.Alone
+}
+
+func (rc *ReplayController) Discard(player *Player, game *Game) *Card {
+	event, ok := game.StateMachine.nextReplayEvent(player, DiscardEvent)
+	if !ok {
+		return nil
+	}
+	card := game.StateMachine.replayCard(player, event)
+	if card != nil && game.Rules.DealerMustPickUp && card == game.TurnedCard {
+		game.StateMachine.failReplay("%s discarded the turned card %s", player.name, card.ToString())
+	}
+	return card
+}
+
+func (rc *ReplayController) PlayCard(player *Player, game *Game) *Card {
+	event, ok := game.StateMachine.nextReplayEvent(player, PlayCardEvent)
+	if !ok {
+		return nil
+	}
+	card := game.StateMachine.replayCard(player, event)
+	if card != nil && !IsCardPlayable(card, player.hand, game.Trump, game.Trick.LeadCard()) {
+		game.StateMachine.failReplay("%s played %s without following suite", player.name, card.ToString())
+	}
+	return card
+}
+
+// replayCard returns the card in the player's hand that the event names. It
+// records an error and returns nil if the player doesn't hold it.
+func (sm *StateMachine) replayCard(player *Player, event Event) *Card {
+	if len(event.Cards) == 0 {
+		sm.failReplay("the %s event for %s has no card", event.Type, player.name)
+		return nil
+	}
+	card := findCard(player.hand, event.Cards[0])
+	if card == nil {
+		sm.failReplay("%s doesn't hold %s", player.name, event.Cards[0].ToString())
+	}
+	return card
+}
+
+// Emit records an event, appends it to the event log if there is one and tells
+// the observer if there is one
+func (sm *StateMachine) Emit(event Event) {
+	sm.Events = append(sm.Events, event)
+	if sm.EventLog != nil {
+		sm.EventLog.Append(event)
+	}
+	if sm.Observer != nil {
+		sm.Observer.OnEvent(event)
+	}
+}
+
+// nextReplayEvent returns the event being replayed that the player's decision
+// should come from. It records an error and returns false if the log doesn't
+// have the decision.
+func (sm *StateMachine) nextReplayEvent(player *Player, types ...EventType) (Event, bool) {
+	next := len(sm.Events)
+	if next >= len(sm.replay) {
+		sm.failReplay("no recorded decision for %s", player.name)
+		return Event{}, false
+	}
+
+	event := sm.replay[next]
+	for _, t := range types {
+		if event.Type == t && event.Player == player.index {
+			return event, true
+		}
+	}
+	sm.failReplay("expected a %v decision from %s but the log has %s from player %d", types, player.name, event.Type, event.Player)
+	return Event{}, false
+}
+
+// failReplay records why the game being replayed couldn't follow the log. Only
+// the first error is kept, Replay stops at the step it happened in.
+func (sm *StateMachine) failReplay(format string, args ...interface{}) {
+	if sm.replayErr == nil {
+		sm.replayErr = fmt.Errorf(format, args...)
+	}
+}
+
+// shuffleDeck shuffles the deck with the next seed and records the seed and the new order. When a game is being replayed
+// the deck is put in the recorded order instead.
+func (g *Game) shuffleDeck() {
+	next := len(g.StateMachine.Events)
+	if next < len(g.StateMachine.replay) && g.StateMachine.replay[next].Type == ShuffleEvent {
+		event := g.StateMachine.replay[next]
+		if err := g.Deck.Arrange(event.Cards); err != nil {
+			g.StateMachine.failReplay("can't replay the shuffle: %v", err)
+			return
+		}
+		g.Deck.ShuffleSeed = event.Seed
+	} else {
+		g.Deck.ShuffleSeed = g.nextShuffleSeed()
+		g.Deck.Shuffle()
+	}
+	g.StateMachine.Emit(Event{Type: ShuffleEvent, Player: -1, Cards: g.Deck.Cards(), Seed: g.Deck.ShuffleSeed})
+}
+
+// nextShuffleSeed returns the seed for the next shuffle. It follows on from the
+// last shuffle in the log, or from the game's RandSeed if there hasn't been one.
+func (g *Game) nextShuffleSeed() int64 {
+	events := g.StateMachine.Events
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].Type == ShuffleEvent {
+			return NextSeed(events[i].Seed)
+		}
+	}
+	return NextSeed(g.RandSeed)
+}
@@ -0,0 +1,194 @@
+package game
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// playBotGame plays a full game between rule bots, recording its events to log
+func playBotGame(t *testing.T, log *bytes.Buffer) *Game {
+	game := NewGame(DefaultRuleSet())
+	game.StateMachine.EventLog = NewEventLog(log)
+	setRuleBots(&game)
+	for game.StateMachine.CurrentState.GetName() != EndGame {
+		game.StateMachine.Step(&game)
+	}
+	assert.NoError(t, game.StateMachine.EventLog.Err())
+	return &game
+}
+
+func TestReplayFullGame(t *testing.T) {
+	var log bytes.Buffer
+	original := playBotGame(t, &log)
+
+	events, err := ReadEvents(&log)
+	assert.NoError(t, err)
+	assert.Equal(t, original.StateMachine.Events, events, "expected the log to hold every event")
+
+	replayed, err := Replay(events, DefaultRuleSet())
+	assert.NoError(t, err)
+	assert.Equal(t, original.DealerIndex, replayed.DealerIndex)
+	assert.Equal(t, original.Deck.Cards(), replayed.Deck.Cards())
+	for i, team := range original.Teams {
+		assert.Equal(t, team.Score, replayed.Teams[i].Score)
+	}
+	for i, player := range original.Players {
+		assert.Equal(t, cardValues(player.hand), cardValues(replayed.Players[i].hand))
+	}
+}
+
+func TestReplayAndContinue(t *testing.T) {
+	var log bytes.Buffer
+	original := playBotGame(t, &log)
+	events := original.StateMachine.Events
+
+	// stop part way through the second hand and let the bots finish the game
+	cut := 0
+	for i, event := range events {
+		if event.Type == PointsEvent {
+			cut = i + 20
+			break
+		}
+	}
+	replayed, err := Replay(events[:cut], DefaultRuleSet())
+	assert.NoError(t, err)
+	assert.Len(t, replayed.StateMachine.Events, cut)
+
+	setRuleBots(replayed)
+	for replayed.StateMachine.CurrentState.GetName() != EndGame {
+		replayed.StateMachine.Step(replayed)
+	}
+
+	// the bots make the same decisions so the game ends the same way
+	assert.Equal(t, events, replayed.StateMachine.Events)
+}
+
+func TestReplayRejectsBadLog(t *testing.T) {
+	var log bytes.Buffer
+	original := playBotGame(t, &log)
+	events := append([]Event{}, original.StateMachine.Events...)
+
+	// replace a pass with a decision the player couldn't have made
+	for i, event := range events {
+		if event.Type == PassEvent {
+			events[i].Type = PlayCardEvent
+			break
+		}
+	}
+	_, err := Replay(events, DefaultRuleSet())
+	assert.Error(t, err)
+}
+
+func TestReplayRejectsCardNotInHand(t *testing.T) {
+	var log bytes.Buffer
+	original := playBotGame(t, &log)
+	events := append([]Event{}, original.StateMachine.Events...)
+
+	// swap the cards of two plays made by different players
+	first := -1
+	for i, event := range events {
+		if event.Type != PlayCardEvent {
+			continue
+		}
+		if first == -1 {
+			first = i
+		} else if event.Player != events[first].Player {
+			events[first].Cards, events[i].Cards = events[i].Cards, events[first].Cards
+			break
+		}
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := Replay(events, DefaultRuleSet())
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		assert.Error(t, err)
+	case <-time.After(3 * time.Second):
+		t.Fatal("expected replay to fail instead of asking for the card again")
+	}
+}
+
+func TestReplayRejectsTurnedDownSuite(t *testing.T) {
+	// play until a game has trump named in the second round of bidding
+	var events []Event
+	for !hasEvent(events, PickSuiteEvent) {
+		var log bytes.Buffer
+		events = append([]Event{}, playBotGame(t, &log).StateMachine.Events...)
+	}
+
+	// name the turned down suite as trump in the second round of bidding
+	var turned Suite
+	for i, event := range events {
+		if event.Type == TurnCardEvent {
+			turned = event.Cards[0].suite
+		}
+		if event.Type == PickSuiteEvent {
+			events[i].Suite = turned
+			break
+		}
+	}
+	_, err := Replay(events, DefaultRuleSet())
+	assert.Error(t, err)
+}
+
+func TestReplayRejectsBadShuffle(t *testing.T) {
+	var log bytes.Buffer
+	original := playBotGame(t, &log)
+	events := append([]Event{}, original.StateMachine.Events...)
+
+	// a shuffle that's missing a card can't be put back in order
+	for i, event := range events {
+		if event.Type == ShuffleEvent {
+			events[i].Cards = event.Cards[1:]
+			break
+		}
+	}
+	_, err := Replay(events, DefaultRuleSet())
+	assert.ErrorContains(t, err, "can't replay the shuffle")
+}
+
+func hasEvent(events []Event, eventType EventType) bool {
+	for _, event := range events {
+		if event.Type == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func TestEveryShuffleHasItsOwnSeed(t *testing.T) {
+	play := func(seed int64) []Event {
+		game := NewGame(DefaultRuleSet())
+		game.RandSeed = seed
+		setRuleBots(&game)
+		for game.StateMachine.CurrentState.GetName() != EndGame {
+			game.StateMachine.Step(&game)
+		}
+		return game.StateMachine.Events
+	}
+
+	events := play(1)
+	assert.Equal(t, events, play(1), "expected the same master seed to give the same game")
+	assert.NotEqual(t, events, play(2), "expected another master seed to give another game")
+
+	// each shuffle is recorded with a new seed that reproduces its order
+	seeds := map[int64]bool{}
+	for _, event := range events {
+		if event.Type != ShuffleEvent {
+			continue
+		}
+		assert.False(t, seeds[event.Seed], "expected seed %d to be used once", event.Seed)
+		seeds[event.Seed] = true
+
+		deck := InitDeck(event.Seed)
+		deck.Shuffle()
+		assert.Equal(t, event.Cards, deck.Cards())
+	}
+	assert.Greater(t, len(seeds), 2)
+}
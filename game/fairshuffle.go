@@ -0,0 +1,120 @@
+package game
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// FairShuffle is a commit-reveal shuffle that lets players check the host didn't
+// stack the deck. The host picks a secret key and publishes the commitment, a
+// hash of the key, before the cards are dealt. Players may then add entropy of
+// their own, which is mixed with the key to make the shuffle's seed. Once the
+// hand is over the key is revealed and anyone can check it matches the
+// commitment and recompute the order of the deck with VerifyShuffle.
+type FairShuffle struct {
+	key     []byte
+	Entropy []string // entropy added by the players after the commitment was published
+}
+
+// NewFairShuffle picks a new secret key
+func NewFairShuffle() (*FairShuffle, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return &FairShuffle{key: key}, nil
+}
+
+// Commitment is the hash of the key, published before the deal
+func (f *FairShuffle) Commitment() string {
+	sum := sha256.Sum256(f.key)
+	return hex.EncodeToString(sum[:])
+}
+
+// Key is the secret key, revealed after the hand
+func (f *FairShuffle) Key() string {
+	return hex.EncodeToString(f.key)
+}
+
+// AddEntropy mixes a player's entropy into the seed. Only hex strings of up to 64
+// characters are accepted, so they can be sent back in a reveal.
+func (f *FairShuffle) AddEntropy(entropy string) bool {
+	if len(entropy) == 0 || len(entropy) > 64 {
+		return false
+	}
+	if _, err := hex.DecodeString(entropy); err != nil {
+		return false
+	}
+	f.Entropy = append(f.Entropy, entropy)
+	return true
+}
+
+// Seed is the seed the deck is shuffled with
+func (f *FairShuffle) Seed() int64 {
+	return fairSeed(f.key, f.Entropy)
+}
+
+// Reveal returns the commitment, key and entropy separated by spaces, which is
+// everything VerifyReveal needs
+func (f *FairShuffle) Reveal() string {
+	return strings.Join(append([]string{f.Commitment(), f.Key()}, f.Entropy...), " ")
+}
+
+func fairSeed(key []byte, entropy []string) int64 {
+	hash := sha256.New()
+	hash.Write(key)
+	for _, e := range entropy {
+		hash.Write([]byte(e))
+	}
+	return int64(binary.BigEndian.Uint64(hash.Sum(nil)))
+}
+
+// VerifyShuffle checks the key matches the commitment and returns the order of
+// the deck it and the entropy shuffle to, top card last
+func VerifyShuffle(commitment string, key string, entropy []string) ([]Card, error) {
+	keyBytes, err := hex.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key: %w", err)
+	}
+	sum := sha256.Sum256(keyBytes)
+	if !strings.EqualFold(hex.EncodeToString(sum[:]), commitment) {
+		return nil, fmt.Errorf("the key doesn't match the commitment %s", commitment)
+	}
+
+	deck := InitDeck(fairSeed(keyBytes, entropy))
+	deck.Shuffle()
+	return deck.Cards(), nil
+}
+
+// VerifyReveal checks a reveal written by FairShuffle.Reveal and returns the
+// order of the deck, top card last
+func VerifyReveal(reveal string) ([]Card, error) {
+	fields := strings.Fields(reveal)
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("expected a commitment and a key but got %q", reveal)
+	}
+	return VerifyShuffle(fields[0], fields[1], fields[2:])
+}
+
+// dealtHand returns the cards the seat is dealt from the deck when dealer deals
+// to a table of players
+func dealtHand(deck []Card, players int, dealer int, seat int) []Card {
+	hand := make([]Card, 0, 5)
+	next := len(deck) - 1
+	for round := 0; round < 2; round++ {
+		for i := 1; i <= players; i++ {
+			count := dealCount(i, round == 0)
+			for j := 0; j < count && next >= 0; j++ {
+				if (dealer+i)%players == seat {
+					hand = append(hand, deck[next])
+				}
+				next--
+			}
+		}
+	}
+	return hand
+}
@@ -0,0 +1,66 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFairShuffleVerifies(t *testing.T) {
+	fair, err := NewFairShuffle()
+	assert.NoError(t, err)
+	assert.True(t, fair.AddEntropy("00ff"))
+	assert.False(t, fair.AddEntropy("not hex"))
+	assert.False(t, fair.AddEntropy(strings.Repeat("a", 65)))
+
+	deck := InitDeck(1)
+	deck.CommitShuffle(fair)
+	deck.Shuffle()
+	assert.Equal(t, fair.Seed(), deck.ShuffleSeed)
+
+	order, err := VerifyReveal(fair.Reveal())
+	assert.NoError(t, err)
+	assert.Equal(t, deck.Cards(), order)
+
+	// the entropy changes the order
+	order, err = VerifyShuffle(fair.Commitment(), fair.Key(), nil)
+	assert.NoError(t, err)
+	assert.NotEqual(t, deck.Cards(), order)
+}
+
+func TestFairShuffleRejectsAnotherKey(t *testing.T) {
+	fair, err := NewFairShuffle()
+	assert.NoError(t, err)
+	other, err := NewFairShuffle()
+	assert.NoError(t, err)
+
+	_, err = VerifyShuffle(fair.Commitment(), other.Key(), nil)
+	assert.Error(t, err)
+	_, err = VerifyReveal(fair.Commitment())
+	assert.Error(t, err)
+}
+
+func TestShuffleCheckFindsStackedHand(t *testing.T) {
+	fair, err := NewFairShuffle()
+	assert.NoError(t, err)
+	order, err := VerifyReveal(fair.Reveal())
+	assert.NoError(t, err)
+
+	check := shuffleCheck{commitment: fair.Commitment()}
+	check.see(PlayerView{Seat: 2, DealerIndex: 1, Names: make([]string, 4), Hand: dealtHand(order, 4, 1, 2)})
+	assert.NoError(t, check.verify(fair.Reveal()))
+
+	// a hand the deck didn't deal
+	check.dealt = dealtHand(order, 4, 1, 3)
+	assert.Error(t, check.verify(fair.Reveal()))
+
+	// entropy the server left out
+	check = shuffleCheck{commitment: fair.Commitment(), entropy: "abcd"}
+	assert.Error(t, check.verify(fair.Reveal()))
+
+	// a reveal for another commitment
+	other, err := NewFairShuffle()
+	assert.NoError(t, err)
+	assert.Error(t, check.verify(other.Reveal()))
+}
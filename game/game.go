@@ -2,100 +2,287 @@ package game
 
 import (
 	"fmt"
+	"log"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 )
 
+// Play is a card played by a seat during a hand
+type Play struct {
+	Seat  int
+	Card  Card
+	Trick int // the number of the trick in the hand, starting at 0
+}
+
 type Game struct {
 	StateMachine       StateMachine
 	Deck               Deck
-	Players            [4]*Player
+	Players            []*Player
+	Teams              []*Team
 	DealerIndex        int
 	PlayerIndex        int
 	TurnedCard         *Card
-	PlayedCards        []*Card
+	RevealedCard       Card    // the card that was turned up this hand, even after it's picked up or turned down
+	DrawnCards         []*Card // cards drawn for dealer, face up until a jack is drawn
+	Trick              Trick   // the trick being played
+	HandPlays          []Play  // every card played this hand, in order
 	Trump              Suite
 	OrderedPlayerIndex int // the player who ordered it up
+	AlonePlayerIndex   int // the player going alone, -1 if nobody is
 	logs               []string
-	RandSeed           int64
+	RandSeed           int64  // the master seed every shuffle's seed is derived from
+	Logger             Logger // receives every message logged, if set
+	Rules              RuleSet
 }
 
-func NewGame() Game {
+func NewGame(rules RuleSet) Game {
 	game := Game{}
+	game.Rules = rules
 	game.StateMachine = NewStateMachine()
-	game.PlayedCards = nil
 	game.logs = make([]string, 0)
 	game.OrderedPlayerIndex = -1
+	game.AlonePlayerIndex = -1
 	game.DealerIndex = 0
 	game.PlayerIndex = 0
-	game.RandSeed = int64(1)
-	game.Players[0] = InitPlayer("Player 1", 0)
-	game.Players[1] = InitPlayer("Player 2", 1)
-	game.Players[2] = InitPlayer("Player 3", 2)
-	game.Players[3] = InitPlayer("Player 4", 3)
+	game.RandSeed = NewSeed()
+	game.Players = make([]*Player, rules.PlayerCount())
+	for i := range game.Players {
+		game.Players[i] = InitPlayer(fmt.Sprintf("Player %d", i+1), i)
+	}
+	game.Teams = newTeams(rules)
 	game.TurnedCard = nil
 	game.Trump = NONE
-	game.PlayedCards = make([]*Card, 0)
+	game.DrawnCards = make([]*Card, 0)
+	game.Trick = NewTrick(0)
+	game.HandPlays = make([]Play, 0)
 	return game
 }
 
+// Log records a message about the game and passes it to the Logger
 func (g *Game) Log(format string, args ...interface{}) {
 	g.logs = append(g.logs, fmt.Sprintf(format, args...))
-	logToFile(format, args...)
+	if g.Logger != nil {
+		g.Logger.Printf(format, args...)
+	}
 }
 
-func logToFile(format string, args ...interface{}) {
+// openLogFile appends the logs of games played in this terminal to log.out
+func openLogFile() (*os.File, Logger, error) {
 	file, err := os.OpenFile("log.out", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
-		fmt.Println("Error opening log file: ", err)
-		return
+		return nil, nil, err
 	}
-	defer file.Close()
-	format += "\n"
-	fmt.Fprintf(file, format, args...)
-}
-
-func DeleteLogFile() {
-	os.Remove("log.out")
+	return file, log.New(file, "", 0), nil
 }
 
+// PlayCard adds a card played by the player whose turn it is to the trick
 func (g *Game) PlayCard(card *Card) {
-	g.PlayedCards = append(g.PlayedCards, card)
+	g.Trick.Add(g.PlayerIndex, card)
+	g.HandPlays = append(g.HandPlays, g.Trick.Plays[g.Trick.Len()-1])
 }
 
-func (g *Game) ReturnPlayedCards() {
-	g.Deck.ReturnCards(&g.PlayedCards)
+// ReturnTrick puts the cards of the trick that was just won back in the deck and
+// starts the next trick
+func (g *Game) ReturnTrick() {
+	g.Deck.ReturnCards(&g.Trick.cards)
+	g.Trick = NewTrick(g.Trick.Number + 1)
 }
 
+// NextPlayer moves the turn to the next player at the table that is playing this hand
 func (g *Game) NextPlayer() {
-	g.PlayerIndex = (g.PlayerIndex + 1) % 4
+	g.PlayerIndex = g.leftOf(g.PlayerIndex)
+	if g.IsSittingOut(g.PlayerIndex) {
+		g.PlayerIndex = g.leftOf(g.PlayerIndex)
+	}
+}
+
+// leftOf returns the seat to the left of seat, which plays after it
+func (g *Game) leftOf(seat int) int {
+	return (seat + 1) % len(g.Players)
 }
 
-func Run() {
-	game := NewGame()
-	display := NewTextDisplay()
+// IsSittingOut returns true if the player's partner is going alone this hand
+func (g *Game) IsSittingOut(index int) bool {
+	return g.AlonePlayerIndex != -1 && index == g.partnerOf(g.AlonePlayerIndex)
+}
+
+// ActivePlayerCount returns the number of players playing cards this hand
+func (g *Game) ActivePlayerCount() int {
+	if g.AlonePlayerIndex != -1 {
+		return len(g.Players) - 1
+	}
+	return len(g.Players)
+}
+
+// RunConfig holds the options for a game played at this terminal
+type RunConfig struct {
+	Bots         []int  // indexes of the seats played by the computer
+	BotStrategy  string // the strategy used by the computer. See NewBotController
+	Rules        RuleSet
+	EventLog     string // file the game's events are written to, if set
+	SaveFile     string // file the game is saved to on Ctrl-C or when "save" is typed
+	LoadFile     string // saved game to pick back up instead of starting a new one
+	HotSeat      bool   // blank the screen between players sharing this terminal
+	NotationFile string // file the game is written to in the game notation when it ends
+	Seed         int64  // master seed for the shuffles of a new game. One is picked from the time if 0
+}
+
+func Run(config RunConfig) {
+	game := NewGame(config.Rules)
+	eventLogFlags := os.O_CREATE | os.O_TRUNC | os.O_WRONLY
+	if config.LoadFile != "" {
+		loaded, err := LoadGame(config.LoadFile)
+		if err != nil {
+			fmt.Println("Error loading game: ", err)
+			return
+		}
+		game = *loaded
+		eventLogFlags = os.O_CREATE | os.O_APPEND | os.O_WRONLY
+	} else if config.Seed != 0 {
+		game.RandSeed = config.Seed
+	}
+
+	logFile, logger, err := openLogFile()
+	if err != nil {
+		fmt.Println("Error opening log file: ", err)
+		return
+	}
+	defer logFile.Close()
+	game.Logger = logger
+
+	if config.EventLog != "" {
+		file, err := os.OpenFile(config.EventLog, eventLogFlags, 0644)
+		if err != nil {
+			fmt.Println("Error opening event log: ", err)
+			return
+		}
+		defer file.Close()
+		game.StateMachine.EventLog = NewEventLog(file)
+	}
+
+	isBot := make([]bool, len(game.Players))
+	for _, seat := range config.Bots {
+		if seat < 0 || seat >= len(game.Players) {
+			fmt.Printf("There is no player %d at a table of %d\n", seat+1, len(game.Players))
+			return
+		}
+		bot, err := NewBotController(config.BotStrategy)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		game.Players[seat].SetController(bot)
+		isBot[seat] = true
+	}
+
+	display, err := NewTextDisplay()
+	if err != nil {
+		fmt.Println("Error opening display: ", err)
+		return
+	}
+	readLine = display.ReadLine
+	selectCard = func(player *Player, selectable []bool, prompt string) (int, string) {
+		return display.SelectCard(player.index, cardValues(player.hand), selectable, prompt)
+	}
+	viewSeat := nextViewSeat(&game, isBot, -1)
+	var seat *hotSeat = nil
+	if config.HotSeat {
+		seat = newHotSeat(display)
+		for i, player := range game.Players {
+			if !isBot[i] {
+				player.SetController(NewHotSeatController(seat))
+			}
+		}
+	}
+
+	// keep a copy of the game from between steps so it can be saved while a
+	// player is being prompted
+	var snapshotLock sync.Mutex
+	snapshot, _ := MarshalGame(&game)
+	saveSnapshot := func() string {
+		snapshotLock.Lock()
+		defer snapshotLock.Unlock()
+		if err := writeSaveFile(config.SaveFile, snapshot); err != nil {
+			return fmt.Sprintf("Error saving game: %s", err)
+		}
+		return fmt.Sprintf("Game saved to %s.", config.SaveFile)
+	}
+	if config.SaveFile != "" {
+		RegisterCommand("save", saveSnapshot)
+	}
 
 	terminate := make(chan os.Signal, 1)
 	signal.Notify(terminate, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
 
 	// start game
+	logsShown := 0
 	go func() {
 		for {
 			game.StateMachine.Step(&game)
-			display.DrawBoard(&game)
+			data, err := MarshalGame(&game)
+			if err == nil {
+				snapshotLock.Lock()
+				snapshot = data
+				snapshotLock.Unlock()
+			}
+			viewSeat = nextViewSeat(&game, isBot, viewSeat)
+			if seat != nil {
+				// only show the hand of whoever has the terminal
+				viewSeat = seat.holder
+			}
+			display.DrawBoard(NewPlayerView(&game, viewSeat))
+			if len(game.logs) > logsShown {
+				display.AddLogs(game.logs[logsShown:]...)
+				display.ShowMessage(game.logs[len(game.logs)-1])
+				logsShown = len(game.logs)
+			}
 			// delay for .5 seconds for animation
 			time.Sleep(100 * time.Millisecond)
 
 			if game.StateMachine.CurrentState.GetName() == EndGame {
+				message := "Game over! Press Ctrl-C to exit."
+				if config.NotationFile != "" {
+					if err := writeNotationFile(config.NotationFile, &game); err != nil {
+						message = fmt.Sprintf("Error writing game notation: %s. %s", err, message)
+					}
+				}
+				display.ShowMessage(message)
 				break
 			}
 		}
 	}()
 
-	sig := <-terminate
-	ClearTerminal()
-	fmt.Printf("Received %s, exiting...\n", sig)
+	// the display catches Ctrl-C, so SIGINT only comes from outside the terminal
+	reason := "Ctrl-C"
+	select {
+	case sig := <-terminate:
+		reason = sig.String()
+	case <-display.Interrupted():
+	}
+	display.Close()
+	fmt.Printf("Received %s, exiting...\n", reason)
+	if config.SaveFile != "" {
+		fmt.Println(saveSnapshot())
+	}
+}
 
+// nextViewSeat picks whose hand is shown at this terminal. It's the player whose
+// turn it is when they're at the keyboard, otherwise the last one that was shown.
+// If every seat is played by the computer no hand is shown.
+func nextViewSeat(game *Game, isBot []bool, lastSeat int) int {
+	if !isBot[game.PlayerIndex] {
+		return game.PlayerIndex
+	}
+	if lastSeat != -1 {
+		return lastSeat
+	}
+	for seat, bot := range isBot {
+		if !bot {
+			return seat
+		}
+	}
+	return -1
 }
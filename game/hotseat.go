@@ -0,0 +1,64 @@
+package game
+
+import "fmt"
+
+// hotSeat tracks who has the terminal when several people share it. Before a
+// player is prompted the screen is blanked until the terminal is passed to
+// them, so nobody sees a hand that isn't theirs.
+type hotSeat struct {
+	display *TextDisplay
+	holder  int // the seat of the player that has the terminal, -1 before anyone does
+}
+
+func newHotSeat(display *TextDisplay) *hotSeat {
+	seat := hotSeat{}
+	seat.display = display
+	seat.holder = -1
+	return &seat
+}
+
+// handTo makes sure the player has the terminal and shows them the board from
+// their seat
+func (h *hotSeat) handTo(player *Player, game *Game) {
+	if h.holder != player.index {
+		h.display.PassTo(fmt.Sprintf("Pass to %s, press Enter", player.name))
+		h.holder = player.index
+	}
+	h.display.DrawBoard(NewPlayerView(game, player.index))
+}
+
+// HotSeatController prompts a human at a shared terminal, blanking the screen
+// until the terminal is passed to them
+type HotSeatController struct {
+	TerminalController
+	seat *hotSeat
+}
+
+func NewHotSeatController(seat *hotSeat) *HotSeatController {
+	return &HotSeatController{seat: seat}
+}
+
+func (hc *HotSeatController) OrderUp(player *Player, game *Game) bool {
+	hc.seat.handTo(player, game)
+	return hc.TerminalController.OrderUp(player, game)
+}
+
+func (hc *HotSeatController) PickSuite(player *Player, game *Game, mustPick bool) Suite {
+	hc.seat.handTo(player, game)
+	return hc.TerminalController.PickSuite(player, game, mustPick)
+}
+
+func (hc *HotSeatController) GoAlone(player *Player, game *Game) bool {
+	hc.seat.handTo(player, game)
+	return hc.TerminalController.GoAlone(player, game)
+}
+
+func (hc *HotSeatController) Discard(player *Player, game *Game) *Card {
+	hc.seat.handTo(player, game)
+	return hc.TerminalController.Discard(player, game)
+}
+
+func (hc *HotSeatController) PlayCard(player *Player, game *Game) *Card {
+	hc.seat.handTo(player, game)
+	return hc.TerminalController.PlayCard(player, game)
+}
@@ -0,0 +1,67 @@
+package game
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// waitForText waits until a row of the screen contains text
+func waitForText(t *testing.T, screen tcell.SimulationScreen, y int, text string) {
+	assert.Eventually(t, func() bool {
+		return strings.Contains(screenText(screen, y), text)
+	}, time.Second, time.Millisecond, "expected %q on row %d", text, y)
+}
+
+func TestHotSeatBlanksScreenBetweenPlayers(t *testing.T) {
+	defer func(original func(string) string) { readLine = original }(readLine)
+	display, screen := newTestDisplay(t, 80, 24)
+	defer display.Close()
+	readLine = display.ReadLine
+
+	game := NewGame(DefaultRuleSet())
+	for game.StateMachine.CurrentState.GetName() != TrumpSelectionOne {
+		game.StateMachine.Step(&game)
+	}
+	seat := newHotSeat(display)
+	controller := NewHotSeatController(seat)
+
+	answers := make(chan bool)
+	ask := func(player *Player) {
+		go func() {
+			answers <- controller.OrderUp(player, &game)
+		}()
+	}
+
+	// the first player has to be handed the terminal before their hand is shown
+	ask(game.Players[1])
+	waitForText(t, screen, 12, "Pass to Player 2, press Enter")
+	assert.NotContains(t, screenText(screen, 20), "(0)", "expected no hand behind the curtain")
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	waitForText(t, screen, 23, "Order it up or pass?")
+	assert.Contains(t, screenText(screen, 21), "(0)", "expected the player's hand at the bottom")
+	screen.InjectKeyBytes([]byte("p"))
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	assert.False(t, <-answers)
+
+	// the same player keeps the terminal
+	ask(game.Players[1])
+	waitForText(t, screen, 23, "Order it up or pass?")
+	screen.InjectKeyBytes([]byte("o"))
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	assert.True(t, <-answers)
+	assert.Equal(t, 1, seat.holder)
+
+	// a different player has to be handed the terminal again
+	ask(game.Players[2])
+	waitForText(t, screen, 12, "Pass to Player 3, press Enter")
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	waitForText(t, screen, 23, "Order it up or pass?")
+	screen.InjectKeyBytes([]byte("p"))
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	assert.False(t, <-answers)
+	assert.Equal(t, 2, seat.holder)
+}
@@ -8,20 +8,44 @@ import (
 	"strings"
 )
 
-// promptUser should prompt the user for input with the given string.
-// the prompt should overwrite the previous prompt and the user's input
+// commands are words that can be typed at any prompt to do something other than answer it
+var commands = make(map[string]func() string)
+
+// RegisterCommand makes typing word at any prompt run handler. The message the
+// handler returns is shown before the prompt is asked again.
+func RegisterCommand(word string, handler func() string) {
+	commands[word] = handler
+}
+
+// readLine shows prompt and returns the line the player types. It reads from
+// stdin until Run points it at the input line of the full screen display.
+var readLine = readStdinLine
+
+func readStdinLine(prompt string) string {
+	fmt.Print("  > " + prompt)
+	input, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	return input
+}
+
+// promptUser prompts the user for input with the given string. If the last
+// answer was invalid or was a command, the prompt says so.
 func promptUser(prompt string, showInvalid bool) string {
-	fmt.Print("\033[1A")  // moves the cursor up 1 line
-	fmt.Print("\r\033[K") // erases the current line
-	fmt.Print("  > ")
-	if showInvalid {
-		fmt.Print("Received invalid input! ")
-	}
+	message := ""
+	for {
+		text := prompt
+		if message != "" {
+			text = message + " " + prompt
+		} else if showInvalid {
+			text = "Received invalid input! " + prompt
+		}
 
-	reader := bufio.NewReader(os.Stdin)
-	fmt.Print(prompt)
-	input, _ := reader.ReadString('\n')
-	return strings.TrimSpace(strings.ToLower(input))
+		input := strings.TrimSpace(strings.ToLower(readLine(text)))
+		handler, ok := commands[input]
+		if !ok {
+			return input
+		}
+		message = handler()
+	}
 }
 
 func isValidSuite(invalidSuite Suite, input string) bool {
@@ -114,31 +138,30 @@ func GetScrewTheDealerInput(player *Player, turnedCard Card) Suite {
 	}
 }
 
-// GetDealersBurnCard prompts the dealer to select a card to discard. The input
-// will be the index of the card in their hand
-func GetDealersBurnCard(dealer *Player) *Card {
+// GetGoAloneInput asks the player that made trump if they want to play the hand without their partner
+func GetGoAloneInput(player *Player) bool {
 	var builder strings.Builder
-	builder.WriteString(fmt.Sprintf("%s: Pick a card to discard: ", dealer.name))
+	builder.WriteString(fmt.Sprintf("%s: Do you want to go alone? (y/n): ", player.name))
 	prompt := builder.String()
 	showInvalid := false
 	for {
 		input := promptUser(prompt, showInvalid)
-		index, err := strconv.Atoi(input)
-		if err != nil {
-			showInvalid = true
-			continue
-		}
-
-		if index < 0 || index >= len(dealer.hand) {
-			showInvalid = true
-			continue
+		if input == "y" {
+			return true
+		} else if input == "n" {
+			return false
 		}
-
-		return dealer.hand[index]
-
+		showInvalid = true
 	}
 }
 
+// GetDealersBurnCard prompts the dealer to select one of the discardable cards
+// in their hand to discard
+func GetDealersBurnCard(dealer *Player, discardable []*Card) *Card {
+	prompt := fmt.Sprintf("%s: Pick a card to discard: ", dealer.name)
+	return pickCard(dealer, discardable, prompt)
+}
+
 // GetSuiteInput prompts the player to select a suite that isn't the invalidSuite
 func GetSuiteInput(player *Player, invalidSuite Suite) Suite {
 	var builder strings.Builder
@@ -177,26 +200,56 @@ func GetSuiteInput(player *Player, invalidSuite Suite) Suite {
 	}
 }
 
-// Prompt the player to select a card from their hand. The input will be the index of the card in their hand
-func GetCardInput(player *Player) *Card {
-	var builder strings.Builder
-	builder.WriteString(fmt.Sprintf("%s: Pick a card: ", player.name))
-	prompt := builder.String()
+// GetCardInput prompts the player to select one of the playable cards in their
+// hand
+func GetCardInput(player *Player, playable []*Card) *Card {
+	prompt := fmt.Sprintf("%s: Pick a card: ", player.name)
+	return pickCard(player, playable, prompt)
+}
+
+// selectCard has the player pick a card from their hand. It returns the index of
+// the card, or -1 and what they typed if they typed an answer instead. Until Run
+// opens the full screen display the player always types the index of the card.
+var selectCard = func(player *Player, selectable []bool, prompt string) (int, string) {
+	return -1, readLine(prompt)
+}
+
+// pickCard asks the player for one of the choices from their hand, either by
+// selecting it or typing its index. Cards that aren't choices can't be picked.
+func pickCard(player *Player, choices []*Card, prompt string) *Card {
+	selectable := make([]bool, len(player.hand))
+	for i, c := range player.hand {
+		for _, choice := range choices {
+			selectable[i] = selectable[i] || c == choice
+		}
+	}
+
+	message := ""
 	showInvalid := false
 	for {
-		input := promptUser(prompt, showInvalid)
-		index, err := strconv.Atoi(input)
-		if err != nil {
-			showInvalid = true
-			continue
+		text := prompt
+		if message != "" {
+			text = message + " " + prompt
+		} else if showInvalid {
+			text = "Received invalid input! " + prompt
 		}
 
-		if index < 0 || index >= len(player.hand) {
-			showInvalid = true
-			continue
+		index, input := selectCard(player, selectable, text)
+		if index == -1 {
+			input = strings.TrimSpace(strings.ToLower(input))
+			if handler, ok := commands[input]; ok {
+				message = handler()
+				continue
+			}
+			if typed, err := strconv.Atoi(input); err == nil {
+				index = typed
+			}
 		}
 
-		return player.hand[index]
-
+		if index >= 0 && index < len(player.hand) && selectable[index] {
+			return player.hand[index]
+		}
+		message = ""
+		showInvalid = true
 	}
 }
@@ -0,0 +1,213 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// handsColumnWidth is the width of the column the hands are drawn in on the full
+// board, room for six full size cards while the dealer picks one up
+const handsColumnWidth = 75
+
+// cardColumnWidth is the width of the played and turned card columns
+const cardColumnWidth = 20
+
+// minStatsWidth is the narrowest the stats and history column can be
+const minStatsWidth = 36
+
+// seatHeight is the number of lines a seat's hand takes on the full board
+const seatHeight = 12
+
+// compactCardWidth is the width of a card on the compact board, including the
+// space after it
+const compactCardWidth = 4
+
+// fullBoard holds the x of the lines between the columns of the full board. Any
+// width beyond the narrowest full board is shared between the played card,
+// turned card and stats columns.
+type fullBoard struct {
+	played int
+	turned int
+	stats  int
+}
+
+func newFullBoard(width int) fullBoard {
+	spare := maxInt(width-fullBoardWidth(), 0) / 3
+	board := fullBoard{}
+	board.played = handsColumnWidth
+	board.turned = board.played + cardColumnWidth + spare
+	board.stats = board.turned + cardColumnWidth + spare
+	return board
+}
+
+// fullBoardWidth returns the width of the narrowest board the full size card art
+// fits on
+func fullBoardWidth() int {
+	return handsColumnWidth + 2*cardColumnWidth + minStatsWidth
+}
+
+// fullBoardHeight returns the height of the shortest board with room for every
+// player's hand in full size card art
+func fullBoardHeight(players int) int {
+	return 3 + players*seatHeight
+}
+
+// isCompact returns true if a board of the given size is too small for the full
+// size card art of every player's hand
+func isCompact(width, height, players int) bool {
+	return width < fullBoardWidth() || height < fullBoardHeight(players)
+}
+
+// tablePosition returns where seat sits at a table of players as seen from the
+// viewer's seat: 0 at the bottom, then left, top and right in the order of play.
+// The right is left empty when there are three players. Spectators see the table
+// from the first seat.
+func tablePosition(seat, viewer, players int) int {
+	if viewer < 0 {
+		viewer = 0
+	}
+	return (seat - viewer + players) % players
+}
+
+// compactCard returns the short form of a card, like " J♥" or "10♠"
+func compactCard(c Card) string {
+	symbol := ""
+	switch c.suite {
+	case HEART:
+		symbol = "♥"
+	case DIAMOND:
+		symbol = "♦"
+	case CLUB:
+		symbol = "♣"
+	case SPADE:
+		symbol = "♠"
+	}
+	return fmt.Sprintf("%3s", c.rank.ToChar()+symbol)
+}
+
+// DrawCompactBoard draws the table seating around the current trick, with the
+// viewer at the bottom and short cards, to fit a board of the given size
+func (t *TextDisplay) DrawCompactBoard(view PlayerView, width, height int) {
+	t.DrawCompactStats(view)
+
+	centerX := width / 2
+	centerY := maxInt(height/2, 6)
+	for seat := range view.Names {
+		handWidth := maxInt(len(view.Hand), view.HandSizes[seat]) * compactCardWidth
+		if t.selecting && seat == t.selectSeat {
+			handWidth = len(t.choices) * compactCardWidth
+		}
+
+		var x, y int
+		switch tablePosition(seat, view.Seat, len(view.Names)) {
+		case 0:
+			x, y = centerX-handWidth/2, height-3
+		case 1:
+			x, y = 1, centerY-1
+		case 2:
+			x, y = centerX-handWidth/2, 2
+		case 3:
+			x, y = width-21, centerY-1
+		}
+		t.DrawText(x, y, t.seatLabel(view, seat))
+		t.DrawCompactHand(x, y+1, view, seat)
+	}
+
+	// the cards on the table go in front of whoever played them
+	if view.State == DrawForDealer {
+		if len(view.PlayedCards) > 0 {
+			t.DrawCompactCard(centerX-2, centerY, view.PlayedCards[len(view.PlayedCards)-1])
+		}
+		return
+	}
+	if view.TurnedCard != nil {
+		t.DrawCompactCard(centerX-2, centerY, *view.TurnedCard)
+	}
+	for _, play := range view.Trick {
+		switch tablePosition(play.Seat, view.Seat, len(view.Names)) {
+		case 0:
+			t.DrawCompactCard(centerX-2, centerY+1, play.Card)
+		case 1:
+			t.DrawCompactCard(centerX-8, centerY, play.Card)
+		case 2:
+			t.DrawCompactCard(centerX-2, centerY-1, play.Card)
+		case 3:
+			t.DrawCompactCard(centerX+4, centerY, play.Card)
+		}
+	}
+}
+
+// seatLabel returns the name of the player in seat, marked if it's their turn
+// or they're the dealer
+func (t *TextDisplay) seatLabel(view PlayerView, seat int) string {
+	label := view.Names[seat]
+	if seat == view.PlayerIndex {
+		label = "▶ " + label
+	}
+	if seat == view.DealerIndex {
+		label += " (D)"
+	}
+	if view.AlonePlayerIndex != -1 && seat == (view.AlonePlayerIndex+2)%4 {
+		label += " (out)"
+	}
+	return label
+}
+
+// DrawCompactHand draws a seat's hand as short cards, face down unless it's the
+// viewer's hand or the cards being selected from
+func (t *TextDisplay) DrawCompactHand(x, y int, view PlayerView, seat int) {
+	if t.selecting && seat == t.selectSeat {
+		t.cardBounds = make([]cardBounds, len(t.choices))
+		for i, card := range t.choices {
+			cardX := x + i*compactCardWidth
+			t.drawCompactText(cardX, y, compactCard(card), t.selectionStyle(i))
+			t.cardBounds[i] = cardBounds{x: cardX, y: y, width: compactCardWidth - 1, height: 1}
+			t.DrawText(cardX, y+1, fmt.Sprintf("(%d)", i))
+		}
+		return
+	}
+
+	if seat == view.Seat {
+		for i, card := range view.Hand {
+			t.DrawCompactCard(x+i*compactCardWidth, y, card)
+			t.DrawText(x+i*compactCardWidth, y+1, fmt.Sprintf("(%d)", i))
+		}
+		return
+	}
+
+	for i := 0; i < view.HandSizes[seat]; i++ {
+		t.drawCompactText(x+i*compactCardWidth, y, "░░░", tcell.StyleDefault.Foreground(tcell.ColorBlue))
+	}
+}
+
+func (t *TextDisplay) DrawCompactCard(x, y int, card Card) {
+	t.drawCompactText(x, y, compactCard(card), t.cardStyle(card))
+}
+
+func (t *TextDisplay) drawCompactText(x, y int, text string, style tcell.Style) {
+	for i, r := range []rune(text) {
+		t.screen.SetContent(x+i, y, r, nil, style)
+	}
+}
+
+// DrawCompactStats draws the score and the state of the hand on the top two lines
+func (t *TextDisplay) DrawCompactStats(view PlayerView) {
+	status := []string{fmt.Sprintf("Trump: %s", view.Trump.ToString())}
+	if view.OrderedPlayerIndex != -1 {
+		status = append(status, fmt.Sprintf("Ordered Up: %s", view.Names[view.OrderedPlayerIndex]))
+	}
+	if view.AlonePlayerIndex != -1 {
+		status = append(status, fmt.Sprintf("Going Alone: %s", view.Names[view.AlonePlayerIndex]))
+	}
+	status = append(status, "F2: History")
+	t.DrawText(1, 0, strings.Join(status, " │ "))
+
+	score := make([]string, 0)
+	for i, name := range view.TeamNames {
+		score = append(score, fmt.Sprintf("%s: %d points, %d tricks", name, view.Points[i], view.Tricks[i]))
+	}
+	score = append(score, fmt.Sprintf("Playing To: %d", view.TargetScore))
+	t.DrawText(1, 1, strings.Join(score, " │ "))
+}
@@ -0,0 +1,403 @@
+package game
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// number of deals sampled per decision when no budget is given
+const defaultMonteCarloIterations = 200
+
+// MonteCarloController is a computer opponent that searches for its decisions.
+// For every decision it deals the cards it can't see to the other players in a
+// way that agrees with everything that has happened this hand, plays the hand
+// out with the rule bot for every choice it has and picks the choice that earned
+// its team the most points on average.
+type MonteCarloController struct {
+	Iterations int           // deals sampled per decision. 0 means no limit
+	TimeLimit  time.Duration // time spent on each decision. 0 means no limit
+	rng        *rand.Rand
+}
+
+// NewMonteCarloController creates a bot with the given budget per decision. If
+// neither budget is set a fixed number of iterations is used.
+func NewMonteCarloController(iterations int, timeLimit time.Duration, seed int64) *MonteCarloController {
+	bot := MonteCarloController{}
+	bot.Iterations = iterations
+	bot.TimeLimit = timeLimit
+	if iterations == 0 && timeLimit == 0 {
+		bot.Iterations = defaultMonteCarloIterations
+	}
+	bot.rng = rand.New(rand.NewSource(seed))
+	return &bot
+}
+
+// OrderUp orders the turned card up if doing so is expected to earn points
+func (bot *MonteCarloController) OrderUp(player *Player, game *Game) bool {
+	return bot.evaluateTrump(player, game, game.TurnedCard.suite, true, false) > 0
+}
+
+// PickSuite picks the suite expected to earn the most points. The bot passes if
+// no suite is expected to earn points, unless it has to pick.
+func (bot *MonteCarloController) PickSuite(player *Player, game *Game, mustPick bool) Suite {
+	bestSuite := NONE
+	bestScore := 0.0
+	for _, suite := range []Suite{DIAMOND, CLUB, HEART, SPADE} {
+		if suite == game.TurnedCard.suite {
+			continue
+		}
+		score := bot.evaluateTrump(player, game, suite, false, false)
+		if bestSuite == NONE || score > bestScore {
+			bestSuite = suite
+			bestScore = score
+		}
+	}
+
+	if mustPick || bestScore > 0 {
+		return bestSuite
+	}
+	return NONE
+}
+
+// GoAlone goes alone if that is expected to earn more points than playing with
+// a partner
+func (bot *MonteCarloController) GoAlone(player *Player, game *Game) bool {
+	pickup := game.TurnedCard != nil
+	alone := bot.evaluateTrump(player, game, game.Trump, pickup, true)
+	together := bot.evaluateTrump(player, game, game.Trump, pickup, false)
+	return alone > together
+}
+
+// Discard throws away the card that leaves the best hand to play with
+func (bot *MonteCarloController) Discard(player *Player, game *Game) *Card {
+	hand := cardValues(player.hand)
+	sampler := newBiddingSampler(player.index, len(game.Players), hand)
+	scores := make([]float64, len(hand))
+
+	start := time.Now()
+	for i := 0; bot.searching(start, i); i++ {
+		hands := sampler.sample(bot.rng)
+		for j := range hand {
+			if game.Rules.DealerMustPickUp && hand[j] == *game.TurnedCard {
+				scores[j] = math.Inf(-1)
+				continue
+			}
+			hands[player.index] = append(append([]Card{}, hand[:j]...), hand[j+1:]...)
+			state := newDealtSimState(hands, game.Trump, game.OrderedPlayerIndex, game.AlonePlayerIndex != -1, game.DealerIndex, game.Rules)
+			state.PlayOut()
+			scores[j] += float64(state.Score(player.index))
+		}
+	}
+
+	return player.hand[bestIndex(scores)]
+}
+
+// PlayCard plays the card that earned the most points on average
+func (bot *MonteCarloController) PlayCard(player *Player, game *Game) *Card {
+	state := NewSimState(game)
+	legalCards := state.LegalCards()
+	if len(legalCards) == 1 {
+		return findCard(player.hand, legalCards[0])
+	}
+
+	sampler := newPlaySampler(player.index, game)
+	scores := make([]float64, len(legalCards))
+
+	start := time.Now()
+	for i := 0; bot.searching(start, i); i++ {
+		hands := sampler.sample(bot.rng)
+		for seat := range hands {
+			if seat != player.index {
+				state.Hands[seat] = hands[seat]
+			}
+		}
+		for j, c := range legalCards {
+			sim := state.Clone()
+			sim.Play(c)
+			sim.PlayOut()
+			scores[j] += float64(sim.Score(player.index))
+		}
+	}
+
+	return findCard(player.hand, legalCards[bestIndex(scores)])
+}
+
+// evaluateTrump returns the average points the player's team earns if the player
+// calls trump. When pickup is true the dealer picks up the turned card, unless
+// their partner is going alone.
+func (bot *MonteCarloController) evaluateTrump(player *Player, game *Game, trump Suite, pickup bool, alone bool) float64 {
+	hand := cardValues(player.hand)
+	turnedCard := game.RevealedCard
+	sampler := newBiddingSampler(player.index, len(game.Players), append(append([]Card{}, hand...), turnedCard))
+	total := 0.0
+
+	start := time.Now()
+	i := 0
+	for ; bot.searching(start, i); i++ {
+		hands := sampler.sample(bot.rng)
+		hands[player.index] = append([]Card{}, hand...)
+		if pickup && !(alone && game.partnerOf(player.index) == game.DealerIndex) {
+			dealerHand := append(hands[game.DealerIndex], turnedCard)
+			burnCard := *chooseBurnCard(cardPointers(dealerHand), trump)
+			hands[game.DealerIndex] = removeCard(dealerHand, burnCard)
+		}
+
+		state := newDealtSimState(hands, trump, player.index, alone, game.DealerIndex, game.Rules)
+		state.PlayOut()
+		total += float64(state.Score(player.index))
+	}
+	return total / float64(i)
+}
+
+// searching returns true while the bot still has budget left for a decision.
+// At least one iteration is always run.
+func (bot *MonteCarloController) searching(start time.Time, iteration int) bool {
+	if iteration == 0 {
+		return true
+	}
+	if bot.Iterations > 0 && iteration >= bot.Iterations {
+		return false
+	}
+	if bot.TimeLimit > 0 && time.Since(start) >= bot.TimeLimit {
+		return false
+	}
+	return true
+}
+
+// newDealtSimState creates the state for a hand that is about to be played
+func newDealtSimState(hands [][]Card, trump Suite, maker int, alone bool, dealer int, rules RuleSet) SimState {
+	state := newSimState(rules)
+	copy(state.Hands, hands) // playing out the hand replaces the state's hands, not the caller's
+	state.Trump = trump
+	state.Maker = maker
+	state.Alone = alone
+	state.Turn = (dealer + 1) % len(hands)
+	if state.IsSittingOut(state.Turn) {
+		state.Turn = (state.Turn + 1) % len(hands)
+	}
+	return state
+}
+
+// handSampler deals the cards a seat can't see to the other seats
+type handSampler struct {
+	seat   int
+	unseen []Card
+	sizes  []int     // the number of cards each other seat is holding
+	known  [][]Card  // cards known to be in a seat's hand
+	voids  [][5]bool // suites a seat is known to be out of, indexed by Suite
+	trump  Suite
+}
+
+// newHandSampler creates a sampler for a table with the given number of seats
+// that knows nothing about their hands yet
+func newHandSampler(seat int, seats int, trump Suite) handSampler {
+	sampler := handSampler{seat: seat, trump: trump}
+	sampler.sizes = make([]int, seats)
+	sampler.known = make([][]Card, seats)
+	sampler.voids = make([][5]bool, seats)
+	return sampler
+}
+
+// newBiddingSampler creates a sampler for before trump is picked, when nothing
+// is known about the other hands. seen are the cards the seat can see.
+func newBiddingSampler(seat int, seats int, seen []Card) *handSampler {
+	sampler := newHandSampler(seat, seats, NONE)
+	sampler.unseen = unseenCards(seen)
+	for i := range sampler.sizes {
+		if i != seat {
+			sampler.sizes[i] = 5
+		}
+	}
+	return &sampler
+}
+
+// newPlaySampler creates a sampler for the trick playing part of a hand. It takes
+// into account the cards that have been played, suites players failed to follow
+// and the turned card if the dealer picked it up.
+func newPlaySampler(seat int, game *Game) *handSampler {
+	sampler := newHandSampler(seat, len(game.Players), game.Trump)
+	player := game.Players[seat]
+
+	seen := cardValues(player.hand)
+	for _, play := range game.HandPlays {
+		seen = append(seen, play.Card)
+	}
+
+	// the turned card is in the dealer's hand if they picked it up and kept it,
+	// otherwise it's out of play. Only the dealer knows what they discarded.
+	revealedCard := game.RevealedCard
+	discard, pickedUp := dealerDiscard(game)
+	if pickedUp && game.DealerIndex == seat {
+		seen = append(seen, discard)
+	}
+	if !containsCard(seen, revealedCard) {
+		seen = append(seen, revealedCard)
+		if pickedUp && discard != revealedCard && game.DealerIndex != seat {
+			sampler.known[game.DealerIndex] = []Card{revealedCard}
+		}
+	}
+	sampler.unseen = unseenCards(seen)
+
+	for i, p := range game.Players {
+		if i != seat {
+			sampler.sizes[i] = len(p.hand)
+		}
+	}
+
+	// players that didn't follow the lead suite are out of it
+	trickLeads := make(map[int]Suite)
+	for _, play := range game.HandPlays {
+		suite := EffectiveSuit(play.Card, game.Trump)
+		lead, ok := trickLeads[play.Trick]
+		if !ok {
+			trickLeads[play.Trick] = suite
+		} else if suite != lead {
+			sampler.voids[play.Seat][lead] = true
+		}
+	}
+
+	return &sampler
+}
+
+// dealerDiscard returns the card the dealer discarded this hand. ok is false if
+// the dealer didn't pick up the turned card, either because trump was named in
+// the second round or because their partner went alone.
+func dealerDiscard(game *Game) (card Card, ok bool) {
+	events := game.StateMachine.Events
+	for i := len(events) - 1; i >= 0 && events[i].Type != TurnCardEvent; i-- {
+		if events[i].Type == DiscardEvent {
+			return events[i].Cards[0], true
+		}
+	}
+	return Card{}, false
+}
+
+// sample deals the unseen cards to the other seats. The seat doing the sampling
+// is given an empty hand. Leftover cards stay in the kitty.
+func (hs *handSampler) sample(rng *rand.Rand) [][]Card {
+	for attempt := 0; attempt < 50; attempt++ {
+		hands, ok := hs.deal(rng, true)
+		if ok {
+			return hands
+		}
+	}
+
+	// the voids are too restrictive to deal around, so ignore them
+	hands, _ := hs.deal(rng, false)
+	return hands
+}
+
+// deal tries to deal the unseen cards. Each card goes to a random seat that has
+// room for it, weighted by how much room each seat has left, with the kitty
+// treated as one more seat.
+func (hs *handSampler) deal(rng *rand.Rand, useVoids bool) ([][]Card, bool) {
+	hands := make([][]Card, len(hs.sizes))
+	kitty := len(hands)
+	needed := make([]int, kitty+1) // the kitty is the last entry
+	for i := range hands {
+		hands[i] = append(make([]Card, 0, hs.sizes[i]), hs.known[i]...)
+		needed[i] = hs.sizes[i] - len(hs.known[i])
+		needed[kitty] -= needed[i]
+	}
+	needed[kitty] += len(hs.unseen)
+
+	cards := append([]Card{}, hs.unseen...)
+	rng.Shuffle(len(cards), func(i, j int) { cards[i], cards[j] = cards[j], cards[i] })
+
+	for _, c := range cards {
+		suite := EffectiveSuit(c, hs.trump)
+		total := 0
+		room := make([]int, len(needed))
+		for i := range room {
+			if needed[i] > 0 && !(useVoids && i < kitty && hs.voids[i][suite]) {
+				room[i] = needed[i]
+				total += room[i]
+			}
+		}
+		if total == 0 {
+			return hands, false
+		}
+
+		pick := rng.Intn(total)
+		for i := range room {
+			if pick < room[i] {
+				if i < kitty {
+					hands[i] = append(hands[i], c)
+				}
+				needed[i] -= 1
+				break
+			}
+			pick -= room[i]
+		}
+	}
+	return hands, true
+}
+
+// unseenCards returns every card in the deck that isn't in seen
+func unseenCards(seen []Card) []Card {
+	unseen := make([]Card, 0)
+	for _, c := range allCards() {
+		if !containsCard(seen, c) {
+			unseen = append(unseen, c)
+		}
+	}
+	return unseen
+}
+
+func containsCard(cards []Card, card Card) bool {
+	for _, c := range cards {
+		if c == card {
+			return true
+		}
+	}
+	return false
+}
+
+// removeCard returns a copy of cards without card
+func removeCard(cards []Card, card Card) []Card {
+	remaining := make([]Card, 0, len(cards))
+	for _, c := range cards {
+		if c != card {
+			remaining = append(remaining, c)
+		}
+	}
+	return remaining
+}
+
+// findCard returns the card in hand with the same rank and suite as card
+func findCard(hand []*Card, card Card) *Card {
+	for _, c := range hand {
+		if *c == card {
+			return c
+		}
+	}
+	return nil
+}
+
+func cardValues(cards []*Card) []Card {
+	values := make([]Card, len(cards))
+	for i, c := range cards {
+		values[i] = *c
+	}
+	return values
+}
+
+func cardPointers(cards []Card) []*Card {
+	pointers := make([]*Card, len(cards))
+	for i := range cards {
+		pointers[i] = &cards[i]
+	}
+	return pointers
+}
+
+// bestIndex returns the index of the highest score
+func bestIndex(scores []float64) int {
+	best := 0
+	for i, score := range scores {
+		if score > scores[best] {
+			best = i
+		}
+	}
+	return best
+}
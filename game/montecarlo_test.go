@@ -0,0 +1,198 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimStateClone(t *testing.T) {
+	state := newSimState(DefaultRuleSet())
+	state.Trump = HEART
+	state.Hands[0] = []Card{{rank: JACK, suite: HEART}}
+	state.Hands[1] = []Card{{rank: NINE, suite: HEART}}
+	state.Hands[2] = []Card{{rank: NINE, suite: CLUB}}
+	state.Hands[3] = []Card{{rank: ACE, suite: CLUB}}
+
+	clone := state.Clone()
+	clone.PlayOut()
+
+	assert.True(t, clone.IsOver(), "expected clone to finish the hand")
+	assert.Equal(t, 1, clone.Tricks[0], "expected the right bauer to win the trick")
+	assert.Len(t, state.Hands[0], 1, "expected original state to be unchanged")
+	assert.Empty(t, state.Trick, "expected original state to be unchanged")
+}
+
+func TestSimStateScore(t *testing.T) {
+	state := newSimState(DefaultRuleSet())
+	state.Maker = 1
+
+	state.Tricks = []int{0, 5}
+	assert.Equal(t, 2, state.Score(1), "expected makers to earn 2 for a march")
+	state.Tricks = []int{2, 3}
+	assert.Equal(t, 1, state.Score(3), "expected makers to earn 1")
+	assert.Equal(t, -1, state.Score(0), "expected defenders to lose 1")
+	state.Tricks = []int{3, 2}
+	assert.Equal(t, 2, state.Score(2), "expected defenders to earn 2 for a euchre")
+}
+
+func TestSimStateCutthroat(t *testing.T) {
+	rules := DefaultRuleSet()
+	rules.Cutthroat = true
+	game := NewGame(rules)
+	game.Trump = SPADE
+	game.OrderedPlayerIndex = 1
+	game.Trick = NewTrick(0)
+	game.PlayerIndex = 2
+	game.Players[0].hand = []*Card{{rank: NINE, suite: HEART}}
+	game.Players[1].hand = []*Card{{rank: JACK, suite: SPADE}}
+	game.Players[2].hand = []*Card{{rank: ACE, suite: HEART}}
+	for _, team := range game.Teams {
+		team.Tricks = 1
+	}
+	game.Teams[1].Tricks = 2
+
+	state := NewSimState(&game)
+	assert.Len(t, state.Hands, 3)
+	assert.Equal(t, 4, state.TrickNumber)
+	assert.Equal(t, -1, state.partnerOf(0), "expected nobody to have a partner")
+
+	state.PlayOut()
+	assert.True(t, state.IsOver())
+	assert.Equal(t, []int{1, 3, 1}, state.Tricks, "expected the right bauer to win the trick")
+	assert.Equal(t, 1, state.Score(1), "expected the maker to earn 1")
+	assert.Equal(t, -1, state.Score(0), "expected a defender to fall behind the maker")
+
+	state.Tricks = []int{2, 2, 1}
+	assert.Equal(t, 0, state.Score(0), "expected both defenders to earn the euchre")
+	assert.Equal(t, -2, state.Score(1), "expected the maker to fall behind the defenders")
+}
+
+func TestHandSamplerVoids(t *testing.T) {
+	game := NewGame(DefaultRuleSet())
+	game.Trump = SPADE
+	game.DealerIndex = 3
+	game.RevealedCard = Card{rank: NINE, suite: DIAMOND}
+	game.Players[0].hand = []*Card{{rank: KING, suite: HEART}, {rank: TEN, suite: DIAMOND}}
+	for _, p := range game.Players[1:] {
+		p.hand = []*Card{{}, {}}
+	}
+
+	// player 2 didn't follow clubs so they can't have any
+	game.PlayerIndex = 0
+	game.PlayCard(&Card{rank: NINE, suite: CLUB})
+	game.PlayerIndex = 1
+	game.PlayCard(&Card{rank: NINE, suite: HEART})
+
+	sampler := newPlaySampler(0, &game)
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		hands := sampler.sample(rng)
+		assert.Empty(t, hands[0], "expected no cards dealt to the sampling seat")
+		for _, c := range hands[1] {
+			assert.NotEqual(t, CLUB, EffectiveSuit(c, SPADE), "expected player 2 to have no clubs")
+		}
+		for _, seat := range []int{1, 2, 3} {
+			assert.Len(t, hands[seat], 2, "expected every hand to be filled")
+			assert.NotContains(t, hands[seat], game.RevealedCard, "expected turned down card to be out of play")
+		}
+	}
+}
+
+// newSamplerGame returns a game part way through the first trick where player 2
+// ordered up the turned card and the dealer, player 4, picked it up
+func newSamplerGame(revealed Card) *Game {
+	game := NewGame(DefaultRuleSet())
+	game.DealerIndex = 3
+	game.RevealedCard = revealed
+	game.makeTrump(1, revealed.suite)
+	game.Trick = NewTrick(0)
+	game.StateMachine.Events = []Event{
+		{Type: TurnCardEvent, Player: 3, Cards: []Card{revealed}},
+		{Type: OrderUpEvent, Player: 1},
+	}
+	for _, p := range game.Players {
+		p.hand = []*Card{{}, {}, {}, {}, {}}
+	}
+	return &game
+}
+
+func TestHandSamplerPartnerAlone(t *testing.T) {
+	revealed := Card{rank: NINE, suite: SPADE}
+	game := newSamplerGame(revealed)
+
+	// the dealer sits out, so the turned card went back to the deck
+	game.AlonePlayerIndex = 1
+	game.StateMachine.Events = append(game.StateMachine.Events, Event{Type: LonerEvent, Player: 1, Alone: true})
+	game.Players[0].hand = cardPointers([]Card{{ACE, HEART}, {KING, HEART}, {QUEEN, HEART}, {JACK, HEART}, {TEN, HEART}})
+
+	sampler := newPlaySampler(0, game)
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		for _, hand := range sampler.sample(rng) {
+			assert.NotContains(t, hand, revealed, "expected the turned card to be out of play")
+		}
+	}
+}
+
+func TestHandSamplerDealerDiscard(t *testing.T) {
+	revealed := Card{rank: NINE, suite: SPADE}
+	discard := Card{rank: NINE, suite: HEART}
+	game := newSamplerGame(revealed)
+	game.StateMachine.Events = append(game.StateMachine.Events,
+		Event{Type: LonerEvent, Player: 1},
+		Event{Type: DiscardEvent, Player: 3, Cards: []Card{discard}},
+	)
+	game.Players[3].hand = cardPointers([]Card{revealed, {ACE, HEART}, {KING, HEART}, {QUEEN, HEART}, {JACK, HEART}})
+
+	// the dealer knows what they threw away
+	sampler := newPlaySampler(3, game)
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		for _, hand := range sampler.sample(rng) {
+			assert.NotContains(t, hand, discard, "expected the dealer's discard to be out of play")
+		}
+	}
+
+	// everyone else knows the dealer holds the turned card
+	sampler = newPlaySampler(0, game)
+	for i := 0; i < 100; i++ {
+		assert.Contains(t, sampler.sample(rng)[3], revealed, "expected the dealer to hold the turned card")
+	}
+}
+
+func TestMonteCarloTakesLastTrick(t *testing.T) {
+	game := NewGame(DefaultRuleSet())
+	game.Trump = SPADE
+	game.OrderedPlayerIndex = 0
+	game.RevealedCard = Card{rank: NINE, suite: DIAMOND}
+	for _, team := range game.Teams {
+		team.Tricks = 2
+	}
+
+	winner := &Card{rank: ACE, suite: HEART}
+	loser := &Card{rank: NINE, suite: CLUB}
+	game.Players[0].hand = []*Card{winner, loser}
+	game.Players[1].hand = []*Card{{rank: KING, suite: DIAMOND}, {rank: QUEEN, suite: DIAMOND}}
+	game.Players[2].hand = []*Card{{rank: TEN, suite: DIAMOND}, {rank: JACK, suite: DIAMOND}}
+	game.Players[3].hand = []*Card{{rank: TEN, suite: CLUB}, {rank: KING, suite: CLUB}}
+	game.PlayerIndex = 0
+
+	bot := NewMonteCarloController(50, 0, 1)
+	assert.Equal(t, winner, bot.PlayCard(game.Players[0], &game), "expected bot to cash its ace")
+}
+
+func TestMonteCarloBotsPlayGame(t *testing.T) {
+	game := NewGame(DefaultRuleSet())
+	for i, player := range game.Players {
+		player.SetController(NewMonteCarloController(10, 0, int64(i)))
+	}
+
+	for steps := 0; game.StateMachine.CurrentState.GetName() != EndGame; steps++ {
+		if steps > 100000 {
+			t.Fatal("game did not finish")
+		}
+		game.StateMachine.Step(&game)
+	}
+}
@@ -0,0 +1,526 @@
+package game
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A game is written in the notation as header tags followed by the draw for
+// the first dealer and every hand that was dealt. Seats are numbered from 1, up to
+// 3 in cutthroat or 4 otherwise, and cards are written like JH or 10S. Lines
+// starting with # are comments. The tags come before everything else.
+//
+//	[Date "2023.06.01"]
+//	[Player1 "Alice"]
+//	...
+//	[Seed "1"]
+//	[Target "10"]
+//
+//	Draw: 9H AS JC
+//
+//	Hand 1
+//	Dealer: 3
+//	Deal 1: 9H 10H JH QH KH
+//	...
+//	Turned: JS
+//	Bidding: 4 pass, 1 pass, 2 order, 2 alone
+//	Discard: 3 9H
+//	Trick 1: 4 AS, 1 9S, 3 JS; won by 3
+//	Points: Team 1 +4
+//
+// Cards are listed in each Deal line in the order they were dealt. Bids are
+// pass, order, pick followed by a suite, and alone or partner once trump is made,
+// except in cutthroat where nobody has a partner. In cutthroat each player is a
+// team, numbered the same as their seat.
+
+// GameRecord is a game read from the notation
+type GameRecord struct {
+	Tags   map[string]string
+	Names  []string
+	Rules  RuleSet
+	Seed   int64
+	Events []Event
+}
+
+// WriteNotation writes the game's events in the notation. Hands that were still
+// being dealt are left out.
+func WriteNotation(w io.Writer, game *Game, date time.Time) error {
+	writer := bufio.NewWriter(w)
+
+	scores := make([]string, len(game.Teams))
+	for i, team := range game.Teams {
+		scores[i] = strconv.Itoa(team.Score)
+	}
+
+	tags := [][2]string{{"Date", date.Format("2006.01.02")}}
+	for i, player := range game.Players {
+		tags = append(tags, [2]string{fmt.Sprintf("Player%d", i+1), player.name})
+	}
+	tags = append(tags, [][2]string{
+		{"Seed", strconv.FormatInt(game.RandSeed, 10)},
+		{"Target", strconv.Itoa(game.Rules.TargetScore)},
+		{"StickTheDealer", strconv.FormatBool(game.Rules.StickTheDealer)},
+		{"LonerPoints", strconv.Itoa(game.Rules.LonerPoints)},
+		{"EuchrePoints", strconv.Itoa(game.Rules.EuchrePoints)},
+		{"DealerMustPickUp", strconv.FormatBool(game.Rules.DealerMustPickUp)},
+		{"Cutthroat", strconv.FormatBool(game.Rules.Cutthroat)},
+		{"Result", strings.Join(scores, "-")},
+	}...)
+	for _, tag := range tags {
+		fmt.Fprintf(writer, "[%s %q]\n", tag[0], tag[1])
+	}
+
+	hand := 0
+	trick := 0
+	deals := make([][]Card, len(game.Players))
+	bids := make([]string, 0)
+	plays := make([]string, 0)
+	flushBids := func() {
+		if len(bids) > 0 {
+			fmt.Fprintf(writer, "Bidding: %s\n", strings.Join(bids, ", "))
+			bids = bids[:0]
+		}
+	}
+
+	for i, event := range game.StateMachine.Events {
+		switch event.Type {
+		case ShuffleEvent:
+			flushBids()
+			if i == 0 {
+				fmt.Fprintf(writer, "\nDraw: %s\n", formatCards(dealerDraws(event.Cards)))
+			}
+			deals = make([][]Card, len(game.Players))
+		case DealEvent:
+			deals[event.Player] = append(deals[event.Player], event.Cards...)
+		case TurnCardEvent:
+			hand++
+			trick = 0
+			fmt.Fprintf(writer, "\nHand %d\nDealer: %d\n", hand, event.Player+1)
+			for seat, cards := range deals {
+				fmt.Fprintf(writer, "Deal %d: %s\n", seat+1, formatCards(cards))
+			}
+			fmt.Fprintf(writer, "Turned: %s\n", event.Cards[0])
+		case OrderUpEvent:
+			bids = append(bids, fmt.Sprintf("%d order", event.Player+1))
+		case PassEvent:
+			bids = append(bids, fmt.Sprintf("%d pass", event.Player+1))
+		case PickSuiteEvent:
+			bids = append(bids, fmt.Sprintf("%d pick %s", event.Player+1, event.Suite))
+		case LonerEvent:
+			if event.Alone {
+				bids = append(bids, fmt.Sprintf("%d alone", event.Player+1))
+			} else {
+				bids = append(bids, fmt.Sprintf("%d partner", event.Player+1))
+			}
+		case DiscardEvent:
+			flushBids()
+			fmt.Fprintf(writer, "Discard: %d %s\n", event.Player+1, event.Cards[0])
+		case PlayCardEvent:
+			flushBids()
+			plays = append(plays, fmt.Sprintf("%d %s", event.Player+1, event.Cards[0]))
+		case TrickWonEvent:
+			trick++
+			fmt.Fprintf(writer, "Trick %d: %s; won by %d\n", trick, strings.Join(plays, ", "), event.Player+1)
+			plays = plays[:0]
+		case PointsEvent:
+			fmt.Fprintf(writer, "Points: Team %d +%d\n", event.Team+1, event.Points)
+		}
+	}
+	flushBids()
+	return writer.Flush()
+}
+
+func writeNotationFile(path string, game *Game) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteNotation(file, game, time.Now()); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// dealerDraws returns the cards drawn from the top of a deck until a jack was
+// drawn to pick the first dealer
+func dealerDraws(deck []Card) []Card {
+	draws := make([]Card, 0)
+	for i := len(deck) - 1; i >= 0; i-- {
+		draws = append(draws, deck[i])
+		if deck[i].rank == JACK {
+			break
+		}
+	}
+	return draws
+}
+
+func formatCards(cards []Card) string {
+	text := make([]string, len(cards))
+	for i, c := range cards {
+		text[i] = c.String()
+	}
+	return strings.Join(text, " ")
+}
+
+// notationReader turns the lines of the notation back into events
+type notationReader struct {
+	record  *GameRecord
+	tagged  bool // the tags have been read into the record
+	players int
+	dealer  int
+	deals   [][]Card
+}
+
+// ReadNotation reads a game written by WriteNotation
+func ReadNotation(r io.Reader) (*GameRecord, error) {
+	record := GameRecord{}
+	record.Tags = make(map[string]string)
+	record.Rules = DefaultRuleSet()
+	record.Seed = 1
+	record.Events = make([]Event, 0)
+	reader := notationReader{record: &record, dealer: -1}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if err := reader.readLine(text); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !reader.tagged {
+		if err := reader.applyTags(); err != nil {
+			return nil, err
+		}
+	}
+	return &record, nil
+}
+
+// Replay rebuilds the game by feeding the record's events through the
+// StateMachine. A move the rules don't allow, like playing a card the player
+// wasn't dealt, is returned as an error.
+func (record *GameRecord) Replay() (*Game, error) {
+	game, err := Replay(record.Events, record.Rules)
+	if game != nil {
+		game.RandSeed = record.Seed
+		for i, name := range record.Names {
+			game.Players[i].name = name
+		}
+	}
+	return game, err
+}
+
+func (nr *notationReader) readLine(text string) error {
+	if strings.HasPrefix(text, "[") {
+		if nr.tagged {
+			return fmt.Errorf("expected the tags before everything else but got %q", text)
+		}
+		return nr.readTag(text)
+	}
+	if !nr.tagged {
+		if err := nr.applyTags(); err != nil {
+			return err
+		}
+	}
+	if strings.HasPrefix(text, "Hand ") {
+		nr.dealer = -1
+		nr.deals = make([][]Card, nr.players)
+		return nil
+	}
+
+	key, value, ok := strings.Cut(text, ":")
+	if !ok {
+		return fmt.Errorf("expected a tag or a line like \"Key: value\" but got %q", text)
+	}
+	value = strings.TrimSpace(value)
+	switch {
+	case key == "Draw":
+		return nr.readDraw(value)
+	case key == "Dealer":
+		seat, err := nr.parseSeat(value)
+		nr.dealer = seat
+		return err
+	case strings.HasPrefix(key, "Deal "):
+		seat, err := nr.parseSeat(strings.TrimPrefix(key, "Deal "))
+		if err != nil {
+			return err
+		}
+		nr.deals[seat], err = ParseHand(value)
+		return err
+	case key == "Turned":
+		return nr.readTurned(value)
+	case key == "Bidding":
+		return nr.readBidding(value)
+	case key == "Discard":
+		seat, card, err := nr.parsePlay(value)
+		if err != nil {
+			return err
+		}
+		nr.emit(Event{Type: DiscardEvent, Player: seat, Cards: []Card{card}})
+		return nil
+	case strings.HasPrefix(key, "Trick "):
+		return nr.readTrick(value)
+	case key == "Points":
+		return nr.readPoints(value)
+	}
+	return fmt.Errorf("unknown line %q", text)
+}
+
+func (nr *notationReader) emit(event Event) {
+	nr.record.Events = append(nr.record.Events, event)
+}
+
+func (nr *notationReader) readTag(text string) error {
+	name, value, ok := strings.Cut(strings.Trim(text, "[]"), " ")
+	if !ok || !strings.HasSuffix(text, "]") {
+		return fmt.Errorf("invalid tag %q", text)
+	}
+	unquoted, err := strconv.Unquote(strings.TrimSpace(value))
+	if err != nil {
+		return fmt.Errorf("invalid tag %q: %w", text, err)
+	}
+	nr.record.Tags[name] = unquoted
+	return nil
+}
+
+// applyTags reads the players, rules and seed from the header tags
+func (nr *notationReader) applyTags() error {
+	nr.tagged = true
+	record := nr.record
+
+	ints := map[string]*int{
+		"Target":       &record.Rules.TargetScore,
+		"LonerPoints":  &record.Rules.LonerPoints,
+		"EuchrePoints": &record.Rules.EuchrePoints,
+	}
+	bools := map[string]*bool{
+		"StickTheDealer":   &record.Rules.StickTheDealer,
+		"DealerMustPickUp": &record.Rules.DealerMustPickUp,
+		"Cutthroat":        &record.Rules.Cutthroat,
+	}
+	// go through the tags in order so the same bad tag is always the one reported
+	names := make([]string, 0, len(record.Tags))
+	for name := range record.Tags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var err error
+	for _, name := range names {
+		value := record.Tags[name]
+		if field, ok := ints[name]; ok {
+			*field, err = strconv.Atoi(value)
+		} else if field, ok := bools[name]; ok {
+			*field, err = strconv.ParseBool(value)
+		} else if name == "Seed" {
+			record.Seed, err = strconv.ParseInt(value, 10, 64)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid %s tag %q", name, value)
+		}
+	}
+
+	nr.players = record.Rules.PlayerCount()
+	nr.deals = make([][]Card, nr.players)
+	record.Names = make([]string, nr.players)
+	for i := range record.Names {
+		record.Names[i] = fmt.Sprintf("Player %d", i+1)
+		if name, ok := record.Tags[fmt.Sprintf("Player%d", i+1)]; ok {
+			record.Names[i] = name
+		}
+	}
+	return nil
+}
+
+// readDraw records the first shuffle, with the drawn cards on top of the deck,
+// and the player that drew the jack as dealer
+func (nr *notationReader) readDraw(value string) error {
+	draws, err := ParseHand(value)
+	if err != nil {
+		return err
+	}
+	if len(draws) == 0 || draws[len(draws)-1].rank != JACK {
+		return fmt.Errorf("expected the draw to end with a jack")
+	}
+
+	deck, err := stackDeck(draws)
+	if err != nil {
+		return err
+	}
+	nr.emit(Event{Type: ShuffleEvent, Player: -1, Cards: deck})
+	nr.emit(Event{Type: DealerEvent, Player: (len(draws) - 1) % nr.players})
+	return nil
+}
+
+// readTurned records the shuffle that dealt the hand, the deal and the turned
+// card once the dealer and every hand are known
+func (nr *notationReader) readTurned(value string) error {
+	turned, err := ParseCard(value)
+	if err != nil {
+		return err
+	}
+	if nr.dealer == -1 {
+		return fmt.Errorf("expected the dealer before the turned card")
+	}
+
+	// deal the cards in the order the DealCards state does: 2 or 3 at a time to
+	// each player starting left of the dealer, then the rest
+	deals := make([]Event, 0)
+	for round := 0; round < 2; round++ {
+		for i := 1; i <= nr.players; i++ {
+			seat := (nr.dealer + i) % nr.players
+			count := dealCount(i, round == 0)
+			hand := nr.deals[seat]
+			if round == 0 && len(hand) < count || round == 1 && len(hand) != 5 {
+				return fmt.Errorf("expected 5 cards dealt to player %d", seat+1)
+			}
+			cards := hand[:count]
+			if round == 1 {
+				cards = hand[5-count:]
+			}
+			deals = append(deals, Event{Type: DealEvent, Player: seat, Cards: cards})
+		}
+	}
+
+	drawn := make([]Card, 0)
+	for _, deal := range deals {
+		drawn = append(drawn, deal.Cards...)
+	}
+	deck, err := stackDeck(append(drawn, turned))
+	if err != nil {
+		return err
+	}
+	nr.emit(Event{Type: ShuffleEvent, Player: -1, Cards: deck})
+	for _, deal := range deals {
+		nr.emit(deal)
+	}
+	nr.emit(Event{Type: TurnCardEvent, Player: nr.dealer, Cards: []Card{turned}})
+	return nil
+}
+
+// stackDeck returns the order of a deck that gives cards in order when cards are
+// drawn from the top. The rest of the deck is underneath in the usual order.
+func stackDeck(drawn []Card) ([]Card, error) {
+	deck := make([]Card, 0, 24)
+	for _, c := range allCards() {
+		if !containsCard(drawn, c) {
+			deck = append(deck, c)
+		}
+	}
+	for i := len(drawn) - 1; i >= 0; i-- {
+		deck = append(deck, drawn[i])
+	}
+	if len(deck) != 24 {
+		return nil, fmt.Errorf("a card is drawn more than once in %s", formatCards(drawn))
+	}
+	return deck, nil
+}
+
+func (nr *notationReader) readBidding(value string) error {
+	for _, bid := range strings.Split(value, ",") {
+		fields := strings.Fields(bid)
+		if len(fields) < 2 {
+			return fmt.Errorf("invalid bid %q", bid)
+		}
+		seat, err := nr.parseSeat(fields[0])
+		if err != nil {
+			return err
+		}
+
+		event := Event{Player: seat}
+		switch {
+		case fields[1] == "pass" && len(fields) == 2:
+			event.Type = PassEvent
+		case fields[1] == "order" && len(fields) == 2:
+			event.Type = OrderUpEvent
+		case fields[1] == "alone" && len(fields) == 2:
+			event.Type = LonerEvent
+			event.Alone = true
+		case fields[1] == "partner" && len(fields) == 2:
+			event.Type = LonerEvent
+		case fields[1] == "pick" && len(fields) == 3:
+			event.Type = PickSuiteEvent
+			event.Suite, err = ParseSuite(fields[2])
+			if err != nil || event.Suite == NONE {
+				return fmt.Errorf("invalid bid %q", bid)
+			}
+		default:
+			return fmt.Errorf("invalid bid %q", bid)
+		}
+		nr.emit(event)
+	}
+	return nil
+}
+
+func (nr *notationReader) readTrick(value string) error {
+	playsText, winnerText, ok := strings.Cut(value, ";")
+	if !ok {
+		return fmt.Errorf("expected the trick to say who won it")
+	}
+	winner, err := nr.parseSeat(strings.TrimPrefix(strings.TrimSpace(winnerText), "won by "))
+	if err != nil {
+		return err
+	}
+
+	var winningCard *Card = nil
+	for _, text := range strings.Split(playsText, ",") {
+		seat, card, err := nr.parsePlay(text)
+		if err != nil {
+			return err
+		}
+		nr.emit(Event{Type: PlayCardEvent, Player: seat, Cards: []Card{card}})
+		if seat == winner {
+			winningCard = &card
+		}
+	}
+	if winningCard == nil {
+		return fmt.Errorf("player %d won a trick they didn't play in", winner+1)
+	}
+	nr.emit(Event{Type: TrickWonEvent, Player: winner, Cards: []Card{*winningCard}})
+	return nil
+}
+
+func (nr *notationReader) readPoints(value string) error {
+	var team, points int
+	teams := len(newTeams(nr.record.Rules))
+	if _, err := fmt.Sscanf(value, "Team %d +%d", &team, &points); err != nil || team < 1 || team > teams {
+		return fmt.Errorf("invalid points %q", value)
+	}
+	nr.emit(Event{Type: PointsEvent, Player: -1, Team: team - 1, Points: points})
+	return nil
+}
+
+// parseSeat reads a seat numbered from 1 and returns its index
+func (nr *notationReader) parseSeat(text string) (int, error) {
+	seat, err := strconv.Atoi(strings.TrimSpace(text))
+	if err != nil || seat < 1 || seat > nr.players {
+		return 0, fmt.Errorf("invalid player %q, expected a number from 1 to %d", text, nr.players)
+	}
+	return seat - 1, nil
+}
+
+// parsePlay reads a seat and a card, like "3 JS"
+func (nr *notationReader) parsePlay(text string) (int, Card, error) {
+	seatText, cardText, ok := strings.Cut(strings.TrimSpace(text), " ")
+	if !ok {
+		return 0, Card{}, fmt.Errorf("expected a player and a card but got %q", text)
+	}
+	seat, err := nr.parseSeat(seatText)
+	if err != nil {
+		return 0, Card{}, err
+	}
+	card, err := ParseCard(cardText)
+	return seat, card, err
+}
@@ -0,0 +1,202 @@
+package game
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotationRoundTrip(t *testing.T) {
+	// a game to one point is a single hand
+	rules := DefaultRuleSet()
+	rules.TargetScore = 1
+	game := NewGame(rules)
+	original := &game
+	setRuleBots(original)
+	for original.StateMachine.CurrentState.GetName() != EndGame {
+		original.StateMachine.Step(original)
+	}
+	original.Players[0].name = "Alice"
+
+	var notation bytes.Buffer
+	date := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, WriteNotation(&notation, original, date))
+	text := notation.String()
+	assert.Contains(t, text, "[Date \"2023.06.01\"]\n")
+	assert.Contains(t, text, "[Player1 \"Alice\"]\n")
+	assert.Contains(t, text, "\nHand 1\n")
+
+	record, err := ReadNotation(strings.NewReader(text))
+	assert.NoError(t, err)
+	// the notation doesn't say how the undealt cards were ordered, so only the
+	// shuffles can differ
+	assert.Equal(t, withoutShuffles(original.StateMachine.Events), withoutShuffles(record.Events))
+	assert.Equal(t, "Alice", record.Names[0])
+	assert.Equal(t, rules, record.Rules)
+
+	replayed, err := record.Replay()
+	assert.NoError(t, err)
+	replayed.StateMachine.Step(replayed)
+	assert.Equal(t, EndGame, replayed.StateMachine.CurrentState.GetName())
+	assert.Equal(t, "Alice", replayed.Players[0].name)
+	for i, team := range original.Teams {
+		assert.Equal(t, team.Score, replayed.Teams[i].Score)
+	}
+}
+
+func TestNotationRoundTripFullGame(t *testing.T) {
+	var log bytes.Buffer
+	original := playBotGame(t, &log)
+
+	var notation bytes.Buffer
+	assert.NoError(t, WriteNotation(&notation, original, time.Now()))
+	record, err := ReadNotation(strings.NewReader(notation.String()))
+	assert.NoError(t, err)
+	assert.Equal(t, withoutShuffles(original.StateMachine.Events), withoutShuffles(record.Events))
+
+	replayed, err := record.Replay()
+	assert.NoError(t, err)
+	replayed.StateMachine.Step(replayed)
+	assert.Equal(t, EndGame, replayed.StateMachine.CurrentState.GetName())
+	for i, team := range original.Teams {
+		assert.Equal(t, team.Score, replayed.Teams[i].Score)
+	}
+}
+
+func TestNotationRoundTripCutthroat(t *testing.T) {
+	original, err := NewEngine(cutthroatEngineConfig(3))
+	assert.NoError(t, err)
+	original.PlayToEnd()
+
+	var notation bytes.Buffer
+	assert.NoError(t, WriteNotation(&notation, original, time.Now()))
+	text := notation.String()
+	assert.Contains(t, text, "[Cutthroat \"true\"]\n")
+	assert.NotContains(t, text, "Player4")
+
+	record, err := ReadNotation(strings.NewReader(text))
+	assert.NoError(t, err)
+	assert.Len(t, record.Names, 3)
+	assert.Equal(t, withoutShuffles(original.StateMachine.Events), withoutShuffles(record.Events))
+
+	replayed, err := record.Replay()
+	assert.NoError(t, err)
+	replayed.StateMachine.Step(replayed)
+	assert.Equal(t, EndGame, replayed.StateMachine.CurrentState.GetName())
+	for i, team := range original.Teams {
+		assert.Equal(t, team.Score, replayed.Teams[i].Score)
+	}
+}
+
+func withoutShuffles(events []Event) []Event {
+	kept := make([]Event, 0)
+	for _, event := range events {
+		if event.Type != ShuffleEvent {
+			kept = append(kept, event)
+		}
+	}
+	return kept
+}
+
+func TestReadNotationHand(t *testing.T) {
+	text := `
+[Player3 "Carol"]
+[Target "1"]
+[StickTheDealer "false"]
+
+# the third card drawn is the first jack, so player 3 deals
+Draw: 9H AS JC
+
+Hand 1
+Dealer: 3
+Deal 1: 9S 10S JS QS KS
+Deal 2: 9C 10C QC KC AC
+Deal 3: 9D 10D JD QD KD
+Deal 4: 9H 10H QH KH AH
+Turned: AD
+Bidding: 4 pass, 1 pass, 2 pass, 3 order, 3 alone
+Discard: 3 9D
+Trick 1: 4 AH, 2 AC, 3 JD; won by 3
+`
+	record, err := ReadNotation(strings.NewReader(text))
+	assert.NoError(t, err)
+	assert.Equal(t, "Carol", record.Names[2])
+	assert.Equal(t, "Player 1", record.Names[0])
+	assert.Equal(t, 1, record.Rules.TargetScore)
+	assert.False(t, record.Rules.StickTheDealer)
+
+	game, err := record.Replay()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, game.DealerIndex)
+	assert.Equal(t, 2, game.AlonePlayerIndex)
+	assert.Equal(t, DIAMOND, game.Trump)
+	assert.Equal(t, 1, game.TeamOf(2).Tricks)
+	hand, _ := ParseHand("10D QD KD AD")
+	assert.Equal(t, hand, cardValues(game.Players[2].hand))
+}
+
+func TestReplayNotationIllegalCard(t *testing.T) {
+	hand := `
+Draw: JC
+
+Hand 1
+Dealer: 1
+Deal 2: 9S 10S JS QS AC
+Deal 3: 9C 10C QC KC KS
+Deal 4: 9D 10D JD QD KD
+Deal 1: 9H 10H QH KH AH
+Turned: AD
+Bidding: 2 pass, 3 pass, 4 order, 4 partner
+Discard: 1 9H
+`
+	tests := map[string]string{
+		"not in hand":   "Trick 1: 2 9S, 3 AS, 4 JD, 1 AD; won by 4",
+		"not following": "Trick 1: 2 9S, 3 9C, 4 JD, 1 AD; won by 4",
+		"discarded":     "Trick 1: 2 9S, 3 KS, 4 JD, 1 9H; won by 4",
+	}
+	record, err := ReadNotation(strings.NewReader(hand + "Trick 1: 2 9S, 3 KS, 4 JD, 1 AD; won by 4"))
+	assert.NoError(t, err)
+	_, err = record.Replay()
+	assert.NoError(t, err, "expected the legal trick to replay")
+
+	for name, trick := range tests {
+		record, err := ReadNotation(strings.NewReader(hand + trick))
+		assert.NoError(t, err, name)
+
+		done := make(chan error)
+		go func() {
+			_, err := record.Replay()
+			done <- err
+		}()
+		select {
+		case err := <-done:
+			assert.Error(t, err, name)
+		case <-time.After(3 * time.Second):
+			t.Fatalf("%s: expected replay to fail instead of asking for the card again", name)
+		}
+	}
+}
+
+func TestReadNotationErrors(t *testing.T) {
+	tests := map[string]string{
+		"bad tag":         `[Target 10]`,
+		"bad rule":        `[Target "ten"]`,
+		"unknown line":    `Dance: 1 2 3`,
+		"draw no jack":    `Draw: 9H AS`,
+		"no dealer":       "Deal 1: 9S 10S JS QS KS\nTurned: AD",
+		"short deal":      "Dealer: 1\nDeal 1: 9S 10S\nTurned: AD",
+		"bad bid":         `Bidding: 1 shout`,
+		"bad seat":        `Bidding: 5 pass`,
+		"winner not seen": `Trick 1: 1 9S, 2 10S; won by 3`,
+		"bad points":      `Points: Team 3 +1`,
+		"cutthroat seat":  "[Cutthroat \"true\"]\nBidding: 4 pass",
+		"late tag":        "Dealer: 1\n[Target \"5\"]",
+	}
+	for name, text := range tests {
+		_, err := ReadNotation(strings.NewReader(text))
+		assert.Error(t, err, name)
+	}
+}
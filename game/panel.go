@@ -0,0 +1,87 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// historyLines returns the lines of the history panel: the bidding and the
+// finished tricks of the hand, then the game log
+func historyLines(view PlayerView, logs []string) []string {
+	lines := []string{"Bidding", "-------"}
+	for _, event := range view.Bidding {
+		lines = append(lines, "  "+describeBid(view, event))
+	}
+
+	lines = append(lines, "", "Tricks", "------")
+	for i, trick := range view.TrickHistory {
+		plays := make([]string, len(trick.Plays))
+		for j, play := range trick.Plays {
+			plays[j] = fmt.Sprintf("%s %s", view.Names[play.Seat], strings.TrimSpace(compactCard(play.Card)))
+		}
+		lines = append(lines, fmt.Sprintf("  %d. %s led: %s", i+1, view.Names[trick.Plays[0].Seat], strings.Join(plays, ", ")))
+		lines = append(lines, fmt.Sprintf("     Won by %s", view.Names[trick.Winner]))
+	}
+
+	lines = append(lines, "", "Log", "---")
+	for _, log := range logs {
+		lines = append(lines, "  "+log)
+	}
+	return lines
+}
+
+// describeBid returns what a player did while trump was being picked
+func describeBid(view PlayerView, event Event) string {
+	name := view.Names[event.Player]
+	switch event.Type {
+	case OrderUpEvent:
+		return fmt.Sprintf("%s ordered it up", name)
+	case PassEvent:
+		return fmt.Sprintf("%s passed", name)
+	case PickSuiteEvent:
+		return fmt.Sprintf("%s picked %s", name, event.Suite.ToString())
+	case LonerEvent:
+		if event.Alone {
+			return fmt.Sprintf("%s is going alone", name)
+		}
+		return fmt.Sprintf("%s is playing with their partner", name)
+	case DiscardEvent:
+		if len(event.Cards) > 0 {
+			return fmt.Sprintf("%s discarded %s", name, event.Cards[0].ToString())
+		}
+		return fmt.Sprintf("%s discarded", name)
+	}
+	return ""
+}
+
+// DrawHistoryPanel draws as many lines of the history as fit in the panel,
+// scrolled up from the newest by the panel's scroll offset
+func (t *TextDisplay) DrawHistoryPanel(x, y, width, height int, view PlayerView) {
+	if width <= 0 || height <= 1 {
+		return
+	}
+	t.DrawText(x, y, fitText("History (PgUp/PgDn to scroll)", width))
+
+	lines := historyLines(view, t.logs)
+	rows := height - 1
+	t.panelScroll = minInt(t.panelScroll, maxInt(len(lines)-rows, 0))
+	start := maxInt(len(lines)-rows-t.panelScroll, 0)
+	for i, line := range lines[start:minInt(start+rows, len(lines))] {
+		t.DrawText(x, y+1+i, fitText(line, width))
+	}
+}
+
+// scrollPanel moves the history panel by lines, positive to go back in time.
+// The lock must be held.
+func (t *TextDisplay) scrollPanel(lines int) {
+	t.panelScroll = maxInt(t.panelScroll+lines, 0)
+}
+
+// fitText cuts text down to width characters
+func fitText(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) > width {
+		return string(runes[:width])
+	}
+	return text
+}
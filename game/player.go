@@ -1,32 +1,43 @@
 package game
 
 type Player struct {
-	hand         []*Card
-	tricksTaken  int
-	name         string
-	index        int
-	playedCard   *Card
-	pointsEarned int
+	hand       []*Card
+	name       string
+	index      int
+	playedCard *Card
+	controller PlayerController
 }
 
 func InitPlayer(name string, index int) *Player {
 	player := Player{}
 	player.hand = make([]*Card, 0)
-	player.tricksTaken = 0
-	player.pointsEarned = 0
 	player.name = name
 	player.index = index
 	player.playedCard = nil
+	player.controller = NewTerminalController()
 
 	return &player
 }
 
+// SetController changes who makes the decisions for this player
+func (p *Player) SetController(controller PlayerController) {
+	p.controller = controller
+}
+
+func (p *Player) GetController() PlayerController {
+	return p.controller
+}
+
 func (p *Player) GetName() string {
 	return p.name
 }
 
-func (p *Player) GetTricksTaken() int {
-	return p.tricksTaken
+func (p *Player) GetIndex() int {
+	return p.index
+}
+
+func (p *Player) GetHand() []*Card {
+	return p.hand
 }
 
 func (p *Player) GiveCards(cards []*Card) {
@@ -44,6 +55,16 @@ func (p *Player) ReturnCards() []*Card {
 	return cards
 }
 
+// HasCard returns true if the card is in the players hand
+func (p *Player) HasCard(card *Card) bool {
+	for _, c := range p.hand {
+		if c == card {
+			return true
+		}
+	}
+	return false
+}
+
 // removes the card from the players hand and returns it
 func (p *Player) ReturnCard(card *Card) *Card {
 	for i, c := range p.hand {
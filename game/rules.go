@@ -0,0 +1,40 @@
+package game
+
+// RuleSet holds the house rules a game is played with
+type RuleSet struct {
+	TargetScore      int  // points needed to win the game
+	StickTheDealer   bool // the dealer must name trump when everyone passes. Otherwise the hand is redealt
+	LonerPoints      int  // points for taking all five tricks alone
+	EuchrePoints     int  // points for the defenders when the makers are euchred
+	DealerMustPickUp bool // when trump is ordered up the dealer can't discard the turned card, only one of the five they were dealt
+	Cutthroat        bool // three players each play for themselves, and the maker plays against the other two
+}
+
+// DefaultRuleSet returns the rules most tables play with
+func DefaultRuleSet() RuleSet {
+	rules := RuleSet{}
+	rules.TargetScore = 10
+	rules.StickTheDealer = true
+	rules.LonerPoints = 4
+	rules.EuchrePoints = 2
+	rules.DealerMustPickUp = false
+	rules.Cutthroat = false
+	return rules
+}
+
+// PlayerCount returns the number of players at the table
+func (r RuleSet) PlayerCount() int {
+	if r.Cutthroat {
+		return 3
+	}
+	return 4
+}
+
+// MarchPoints returns the points for the makers taking all five tricks without
+// going alone
+func (r RuleSet) MarchPoints() int {
+	if r.Cutthroat {
+		return 3
+	}
+	return 2
+}
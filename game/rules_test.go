@@ -0,0 +1,221 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// passController passes on trump every time it's asked
+type passController struct {
+	RuleBotController
+}
+
+func (pc *passController) OrderUp(player *Player, game *Game) bool {
+	return false
+}
+
+func (pc *passController) PickSuite(player *Player, game *Game, mustPick bool) Suite {
+	if mustPick {
+		return pc.RuleBotController.PickSuite(player, game, mustPick)
+	}
+	return NONE
+}
+
+func TestRedealWhenEveryonePasses(t *testing.T) {
+	rules := DefaultRuleSet()
+	rules.StickTheDealer = false
+	game := NewGame(rules)
+	for _, player := range game.Players {
+		player.SetController(&passController{})
+	}
+
+	for game.StateMachine.CurrentState.GetName() != TrumpSelectionTwo {
+		game.StateMachine.Step(&game)
+	}
+	dealer := game.DealerIndex
+	for game.StateMachine.CurrentState.GetName() == TrumpSelectionTwo {
+		game.StateMachine.Step(&game)
+	}
+
+	assert.Equal(t, ResetDeckAndShuffle, game.StateMachine.CurrentState.GetName(), "expected the hand to be redealt")
+	assert.Equal(t, (dealer+1)%4, game.DealerIndex, "expected the deal to pass to the left")
+	assert.Equal(t, 24, game.Deck.Length(), "expected every card to be returned to the deck")
+}
+
+func TestStickTheDealer(t *testing.T) {
+	game := NewGame(DefaultRuleSet())
+	for _, player := range game.Players {
+		player.SetController(&passController{})
+	}
+
+	for game.StateMachine.CurrentState.GetName() != StartRound {
+		game.StateMachine.Step(&game)
+	}
+	assert.Equal(t, game.DealerIndex, game.OrderedPlayerIndex, "expected the dealer to be stuck with trump")
+}
+
+func TestTargetScore(t *testing.T) {
+	rules := DefaultRuleSet()
+	rules.TargetScore = 1
+	game := NewGame(rules)
+	setRuleBots(&game)
+
+	hands := 0
+	for game.StateMachine.CurrentState.GetName() != EndGame {
+		if game.StateMachine.CurrentState.GetName() == GivePoints {
+			hands += 1
+		}
+		game.StateMachine.Step(&game)
+	}
+	assert.Equal(t, 1, hands, "expected the first hand to win a game to 1")
+}
+
+func TestCutthroatGame(t *testing.T) {
+	rules := DefaultRuleSet()
+	rules.Cutthroat = true
+	game := NewGame(rules)
+	assert.Len(t, game.Players, 3)
+	assert.Len(t, game.Teams, 3)
+	setRuleBots(&game)
+	game.Players[0].SetController(NewMonteCarloController(0, 0, 1))
+
+	deck := []Card{}
+	for game.StateMachine.CurrentState.GetName() != EndGame {
+		state := game.StateMachine.CurrentState.GetName()
+		game.StateMachine.Step(&game)
+		if state == ResetDeckAndShuffle {
+			deck = game.Deck.Cards()
+		}
+
+		// everyone is dealt five cards the same way dealtHand deals them
+		if state == RevealTopCard {
+			for seat, player := range game.Players {
+				assert.ElementsMatch(t, dealtHand(deck, 3, game.DealerIndex, seat), cardValues(player.hand))
+			}
+			assert.Equal(t, 24-15-1, game.Deck.Length())
+		}
+	}
+
+	for _, event := range game.StateMachine.Events {
+		assert.NotEqual(t, LonerEvent, event.Type, "expected nobody to be asked to go alone")
+		assert.Less(t, event.Player, 3)
+		if event.Type == PointsEvent {
+			assert.Contains(t, []int{1, 2, 3}, event.Points)
+		}
+	}
+	assert.NotNil(t, game.WinningTeam())
+}
+
+func TestLonerAndEuchrePoints(t *testing.T) {
+	tests := []struct {
+		name   string
+		alone  bool
+		tricks int // tricks the makers took
+		points int // points awarded for the hand, to the makers or the defenders
+	}{
+		{"loner marches", true, 5, 6},
+		{"loner takes four", true, 4, 1},
+		{"makers euchred", false, 2, 3},
+		{"loner euchred", true, 1, 3},
+	}
+	for _, test := range tests {
+		rules := DefaultRuleSet()
+		rules.LonerPoints = 6
+		rules.EuchrePoints = 3
+		game := NewGame(rules)
+		game.makeTrump(1, CLUB)
+		if test.alone {
+			game.AlonePlayerIndex = 1
+		}
+		game.MakerTeam().Tricks = test.tricks
+		game.DefendingTeams()[0].Tricks = 5 - test.tricks
+
+		(&GivePointsState{}).DoState(&game)
+		team := game.Teams[1]
+		if test.tricks < 3 {
+			team = game.Teams[0]
+		}
+		assert.Equal(t, test.points, team.Score, test.name)
+		assert.Equal(t, []Event{{Type: PointsEvent, Player: -1, Team: team.Index, Points: test.points}}, game.StateMachine.Events, test.name)
+	}
+}
+
+func TestEuchreWorthNothing(t *testing.T) {
+	rules := DefaultRuleSet()
+	rules.EuchrePoints = 0
+	game := NewGame(rules)
+	game.makeTrump(0, CLUB)
+	game.MakerTeam().Tricks = 2
+	game.DefendingTeams()[0].Tricks = 3
+
+	(&GivePointsState{}).DoState(&game)
+	for _, team := range game.Teams {
+		assert.Equal(t, 0, team.Score)
+	}
+	assert.Empty(t, game.StateMachine.Events, "expected no points to be awarded")
+}
+
+// discardController discards the given cards in order, one each time it's asked
+type discardController struct {
+	RuleBotController
+	discards []*Card
+}
+
+func (dc *discardController) Discard(player *Player, game *Game) *Card {
+	card := dc.discards[0]
+	dc.discards = dc.discards[1:]
+	return card
+}
+
+// newPickupGame returns a game where player 2 ordered up the turned card and
+// the dealer, player 1, is about to pick it up
+func newPickupGame(rules RuleSet, turned *Card, hand []*Card) *Game {
+	game := NewGame(rules)
+	game.DealerIndex = 0
+	game.TurnedCard = turned
+	game.RevealedCard = *turned
+	game.makeTrump(1, turned.suite)
+	game.Players[0].hand = hand
+	return &game
+}
+
+func TestDealerMustPickUp(t *testing.T) {
+	turned := &Card{rank: NINE, suite: SPADE}
+	hand := cardPointers([]Card{{ACE, HEART}, {KING, HEART}, {QUEEN, HEART}, {JACK, HEART}, {TEN, HEART}})
+	notHeld := &Card{rank: ACE, suite: CLUB}
+
+	rules := DefaultRuleSet()
+	rules.DealerMustPickUp = true
+	game := newPickupGame(rules, turned, hand)
+	dealer := game.Players[0]
+	dealer.SetController(&discardController{discards: []*Card{notHeld, turned, hand[4]}})
+
+	// a card the dealer doesn't hold and the turned card are both refused
+	for i := 0; i < 2; i++ {
+		next := (&DealerPickupTrumpState{}).DoState(game)
+		assert.Equal(t, DealerPickupTrump, next, "expected the dealer to be asked again")
+		assert.True(t, dealer.HasCard(turned), "expected the dealer to keep the turned card")
+		assert.Len(t, dealer.hand, 6)
+		assert.Empty(t, game.StateMachine.Events)
+	}
+
+	next := (&DealerPickupTrumpState{}).DoState(game)
+	assert.Equal(t, StartRound, next)
+	assert.True(t, dealer.HasCard(turned))
+	assert.False(t, dealer.HasCard(hand[4]))
+	assert.Equal(t, []Event{{Type: DiscardEvent, Player: 0, Cards: []Card{{TEN, HEART}}}}, game.StateMachine.Events)
+}
+
+func TestDealerCanDiscardTurnedCard(t *testing.T) {
+	turned := &Card{rank: NINE, suite: SPADE}
+	hand := cardPointers([]Card{{ACE, HEART}, {KING, HEART}, {QUEEN, HEART}, {JACK, HEART}, {TEN, HEART}})
+	game := newPickupGame(DefaultRuleSet(), turned, hand)
+	dealer := game.Players[0]
+	dealer.SetController(&discardController{discards: []*Card{turned}})
+
+	next := (&DealerPickupTrumpState{}).DoState(game)
+	assert.Equal(t, StartRound, next)
+	assert.False(t, dealer.HasCard(turned))
+	assert.Len(t, dealer.hand, 5)
+}
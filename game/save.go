@@ -0,0 +1,187 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// the version of the save file format written by MarshalGame
+const saveVersion = 1
+
+// savedGame is the form a Game takes in a save file
+type savedGame struct {
+	Version            int           `json:"version"`
+	State              StateName     `json:"state"`
+	Rules              RuleSet       `json:"rules"`
+	RandSeed           int64         `json:"randSeed"`
+	ShuffleSeed        int64         `json:"shuffleSeed"`
+	Deck               []Card        `json:"deck"`
+	Players            []savedPlayer `json:"players"`
+	Teams              []savedTeam   `json:"teams"`
+	DealerIndex        int           `json:"dealerIndex"`
+	PlayerIndex        int           `json:"playerIndex"`
+	OrderedPlayerIndex int           `json:"orderedPlayerIndex"`
+	AlonePlayerIndex   int           `json:"alonePlayerIndex"`
+	Trump              Suite         `json:"trump"`
+	TurnedCard         *Card         `json:"turnedCard"`
+	RevealedCard       Card          `json:"revealedCard"`
+	DrawnCards         []Card        `json:"drawnCards"`
+	Trick              Trick         `json:"trick"`
+	HandPlays          []Play        `json:"handPlays"`
+	Events             []Event       `json:"events"`
+	Logs               []string      `json:"logs"`
+}
+
+type savedPlayer struct {
+	Name       string `json:"name"`
+	Hand       []Card `json:"hand"`
+	PlayedCard *Card  `json:"playedCard"`
+}
+
+type savedTeam struct {
+	Score  int `json:"score"`
+	Tricks int `json:"tricks"`
+}
+
+// MarshalGame converts everything needed to pick a game back up into JSON.
+// Controllers aren't saved, so they have to be set again after loading.
+func MarshalGame(game *Game) ([]byte, error) {
+	saved := savedGame{}
+	saved.Version = saveVersion
+	saved.State = game.StateMachine.CurrentState.GetName()
+	saved.Rules = game.Rules
+	saved.RandSeed = game.RandSeed
+	saved.ShuffleSeed = game.Deck.ShuffleSeed
+	saved.Deck = game.Deck.Cards()
+	saved.DealerIndex = game.DealerIndex
+	saved.PlayerIndex = game.PlayerIndex
+	saved.OrderedPlayerIndex = game.OrderedPlayerIndex
+	saved.AlonePlayerIndex = game.AlonePlayerIndex
+	saved.Trump = game.Trump
+	saved.RevealedCard = game.RevealedCard
+	saved.DrawnCards = cardValues(game.DrawnCards)
+	saved.Trick = game.Trick
+	saved.HandPlays = game.HandPlays
+	saved.Events = game.StateMachine.Events
+	saved.Logs = game.logs
+
+	if game.TurnedCard != nil {
+		turnedCard := *game.TurnedCard
+		saved.TurnedCard = &turnedCard
+	}
+
+	for _, player := range game.Players {
+		p := savedPlayer{}
+		p.Name = player.name
+		p.Hand = cardValues(player.hand)
+		if player.playedCard != nil {
+			playedCard := *player.playedCard
+			p.PlayedCard = &playedCard
+		}
+		saved.Players = append(saved.Players, p)
+	}
+
+	for _, team := range game.Teams {
+		saved.Teams = append(saved.Teams, savedTeam{Score: team.Score, Tricks: team.Tricks})
+	}
+
+	return json.MarshalIndent(saved, "", "  ")
+}
+
+// UnmarshalGame rebuilds a game saved by MarshalGame. Every player is given a
+// TerminalController.
+func UnmarshalGame(data []byte) (*Game, error) {
+	var saved savedGame
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, err
+	}
+	if saved.Version != saveVersion {
+		return nil, fmt.Errorf("unsupported save version %d", saved.Version)
+	}
+	if len(saved.Players) != saved.Rules.PlayerCount() {
+		return nil, fmt.Errorf("expected %d players but the save has %d", saved.Rules.PlayerCount(), len(saved.Players))
+	}
+
+	state := NewState(saved.State)
+	if state == nil {
+		return nil, fmt.Errorf("unknown state %q", saved.State)
+	}
+
+	game := NewGame(saved.Rules)
+	game.StateMachine.CurrentState = state
+	game.StateMachine.Events = saved.Events
+	game.RandSeed = saved.RandSeed
+	game.Deck = Deck{cards: cardPointers(saved.Deck), ShuffleSeed: saved.ShuffleSeed}
+	game.DealerIndex = saved.DealerIndex
+	game.PlayerIndex = saved.PlayerIndex
+	game.OrderedPlayerIndex = saved.OrderedPlayerIndex
+	game.AlonePlayerIndex = saved.AlonePlayerIndex
+	game.Trump = saved.Trump
+	game.RevealedCard = saved.RevealedCard
+	game.HandPlays = append(game.HandPlays, saved.HandPlays...)
+	game.DrawnCards = cardPointers(saved.DrawnCards)
+	game.Trick.Number = saved.Trick.Number
+	for i, c := range cardPointers(saved.Trick.Cards()) {
+		game.Trick.Add(saved.Trick.Plays[i].Seat, c)
+	}
+	game.logs = append(game.logs, saved.Logs...)
+
+	for i, p := range saved.Players {
+		player := InitPlayer(p.Name, i)
+		player.hand = cardPointers(p.Hand)
+
+		// the card being played is still in the player's hand
+		if p.PlayedCard != nil {
+			player.playedCard = findCard(player.hand, *p.PlayedCard)
+		}
+
+		// the dealer may already be holding the turned card
+		if saved.TurnedCard != nil && game.TurnedCard == nil {
+			game.TurnedCard = findCard(player.hand, *saved.TurnedCard)
+		}
+		game.Players[i] = player
+	}
+
+	if saved.TurnedCard != nil && game.TurnedCard == nil {
+		turnedCard := *saved.TurnedCard
+		game.TurnedCard = &turnedCard
+	}
+
+	if len(saved.Teams) != len(game.Teams) {
+		return nil, fmt.Errorf("expected %d teams but the save has %d", len(game.Teams), len(saved.Teams))
+	}
+	for i, t := range saved.Teams {
+		game.Teams[i].Score = t.Score
+		game.Teams[i].Tricks = t.Tricks
+	}
+
+	return &game, nil
+}
+
+// SaveGame writes the game to a file. The file is replaced in one step so a
+// failed save never leaves a half written file behind.
+func SaveGame(game *Game, path string) error {
+	data, err := MarshalGame(game)
+	if err != nil {
+		return err
+	}
+	return writeSaveFile(path, data)
+}
+
+// LoadGame reads a game written by SaveGame
+func LoadGame(path string) (*Game, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return UnmarshalGame(data)
+}
+
+func writeSaveFile(path string, data []byte) error {
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
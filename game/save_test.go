@@ -0,0 +1,79 @@
+package game
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setRuleBots(game *Game) {
+	for _, player := range game.Players {
+		player.SetController(NewRuleBotController())
+	}
+}
+
+func TestSaveAndResume(t *testing.T) {
+	original := NewGame(DefaultRuleSet())
+	setRuleBots(&original)
+
+	// stop in the middle of every kind of state and make sure the game picks
+	// back up exactly where it stopped
+	stopped := make(map[StateName]bool)
+	for original.StateMachine.CurrentState.GetName() != EndGame {
+		name := original.StateMachine.CurrentState.GetName()
+		if !stopped[name] {
+			stopped[name] = true
+
+			data, err := MarshalGame(&original)
+			assert.NoError(t, err)
+			resumed, err := UnmarshalGame(data)
+			assert.NoError(t, err)
+			assert.Equal(t, name, resumed.StateMachine.CurrentState.GetName())
+			setRuleBots(resumed)
+
+			// both games should play out the same way
+			other, err := UnmarshalGame(data)
+			assert.NoError(t, err)
+			setRuleBots(other)
+			for i := 0; i < 50 && resumed.StateMachine.CurrentState.GetName() != EndGame; i++ {
+				resumed.StateMachine.Step(resumed)
+				other.StateMachine.Step(other)
+			}
+			assert.Equal(t, other.StateMachine.Events, resumed.StateMachine.Events)
+		}
+		original.StateMachine.Step(&original)
+	}
+	assert.True(t, stopped[DealerPickupTrump] || stopped[TrumpSelectionTwo], "expected trump to be picked")
+}
+
+func TestSaveMatchesOriginal(t *testing.T) {
+	original := NewGame(DefaultRuleSet())
+	setRuleBots(&original)
+	for original.StateMachine.CurrentState.GetName() != DealerPickupTrump {
+		original.StateMachine.Step(&original)
+	}
+
+	path := filepath.Join(t.TempDir(), "game.save")
+	assert.NoError(t, SaveGame(&original, path))
+	loaded, err := LoadGame(path)
+	assert.NoError(t, err)
+
+	for original.StateMachine.CurrentState.GetName() != EndGame {
+		original.StateMachine.Step(&original)
+	}
+	setRuleBots(loaded)
+	for loaded.StateMachine.CurrentState.GetName() != EndGame {
+		loaded.StateMachine.Step(loaded)
+	}
+
+	assert.Equal(t, original.StateMachine.Events, loaded.StateMachine.Events)
+	for i, team := range original.Teams {
+		assert.Equal(t, team.Score, loaded.Teams[i].Score)
+	}
+}
+
+func TestLoadRejectsUnknownVersion(t *testing.T) {
+	_, err := UnmarshalGame([]byte(`{"version": 99}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,382 @@
+package game
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// The server and its clients talk in lines of text. Every line starts with a
+// message type followed by its arguments separated by spaces.
+//
+// Client to server:
+//
+//	HELLO <name>            sent once after connecting
+//	<answer>                the answer to the last PROMPT
+//	<entropy>               the answer to ENTROPY, up to 64 hex characters
+//
+// Server to client:
+//
+//	WELCOME <seat> <name>   the seat the client is playing
+//	VIEW <json>             the client's PlayerView of the game
+//	LOG <text>              something happened in the game
+//	PROMPT <kind> <text>    the client has to make a decision
+//	INVALID                 the answer to the last prompt wasn't valid
+//	COMMIT <commitment>     the hash of the secret key of the next shuffle
+//	ENTROPY                 the client can add entropy to the next shuffle
+//	REVEAL <commitment> <key> [<entropy>...]
+//	                        the key and entropy of a shuffle once its hand is over
+//	END                     the game is over
+//
+// Every shuffle is a FairShuffle: its commitment is sent before it happens and
+// it's revealed when the hand is over, so clients can check the deck wasn't
+// stacked. ENTROPY is only sent if the server asks clients for entropy.
+//
+// Prompt kinds are ORDER (o/p), SUITE (h/d/c/s or n to pass), MUSTSUITE
+// (h/d/c/s), ALONE (y/n), DISCARD and PLAY (index of a card in the hand).
+
+// ServerConfig holds the options for hosting a game over the network
+type ServerConfig struct {
+	Address     string
+	Bots        []int  // indexes of the seats played by the computer
+	BotStrategy string // the strategy used by the computer. See NewBotController
+	Rules       RuleSet
+	Seed        int64  // master seed for the shuffles. One is picked from the time if 0
+	Entropy     bool   // ask the clients for entropy to mix into every shuffle
+	Logger      Logger // receives the game's log messages, if set
+}
+
+// Serve hosts a game. It waits for a remote player to connect to every seat that
+// isn't played by the computer and then plays the game to the end. The server's
+// StateMachine is the only copy of the game. Clients are sent what they need to
+// show and are asked for a decision when it's their turn.
+func Serve(config ServerConfig) error {
+	listener, err := net.Listen("tcp", config.Address)
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+
+	logFile, logger, err := openLogFile()
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+	config.Logger = logger
+
+	fmt.Printf("Hosting game on %s\n", listener.Addr())
+	_, err = serveGame(listener, config)
+	return err
+}
+
+// gameServer sends updates about a game to the clients playing it
+type gameServer struct {
+	game    *Game
+	clients []*remoteClient
+	logs    int          // the number of game logs sent to the clients so far
+	entropy bool         // ask the clients for entropy to mix into every shuffle
+	fair    *FairShuffle // the last shuffle committed to, until it's revealed
+}
+
+func serveGame(listener net.Listener, config ServerConfig) (*Game, error) {
+	game := NewGame(config.Rules)
+	if config.Seed != 0 {
+		game.RandSeed = config.Seed
+	}
+	game.Logger = config.Logger
+	server := gameServer{game: &game, entropy: config.Entropy}
+	server.clients = make([]*remoteClient, len(game.Players))
+	defer server.close()
+
+	isBot := make([]bool, len(game.Players))
+	for _, seat := range config.Bots {
+		if seat < 0 || seat >= len(game.Players) {
+			return nil, fmt.Errorf("there is no player %d at a table of %d", seat+1, len(game.Players))
+		}
+		bot, err := NewBotController(config.BotStrategy)
+		if err != nil {
+			return nil, err
+		}
+		game.Players[seat].SetController(bot)
+		isBot[seat] = true
+	}
+
+	// seat the remote players in the open seats as they connect
+	for seat := range game.Players {
+		if isBot[seat] {
+			continue
+		}
+
+		conn, err := listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		client := newRemoteClient(conn)
+		name, err := client.readHello()
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+
+		player := game.Players[seat]
+		player.name = name
+		player.SetController(NewRemoteController(client))
+		server.clients[seat] = client
+		client.send("WELCOME", strconv.Itoa(seat), name)
+		game.Log("%s joined as player %d", name, seat+1)
+		server.sendUpdates()
+	}
+
+	for game.StateMachine.CurrentState.GetName() != EndGame {
+		switch game.StateMachine.CurrentState.GetName() {
+		case InitGame, ResetDeckAndShuffle:
+			if err := server.commitShuffle(); err != nil {
+				return nil, err
+			}
+		}
+		game.StateMachine.Step(&game)
+		server.sendUpdates()
+	}
+	server.revealShuffle()
+
+	for _, client := range server.clients {
+		if client != nil {
+			client.send("END")
+		}
+	}
+	return &game, nil
+}
+
+// sendUpdates sends each client their view of the game if it changed, and any
+// new game logs
+func (s *gameServer) sendUpdates() {
+	newLogs := s.game.logs[s.logs:]
+	s.logs = len(s.game.logs)
+
+	for seat, client := range s.clients {
+		if client == nil {
+			continue
+		}
+
+		view, err := json.Marshal(NewPlayerView(s.game, seat))
+		if err == nil && string(view) != client.lastView {
+			client.send("VIEW", string(view))
+			client.lastView = string(view)
+		}
+		for _, log := range newLogs {
+			client.send("LOG", log)
+		}
+	}
+}
+
+// commitShuffle reveals the last shuffle, since its hand is over, and commits to
+// the next one. Clients are asked for entropy once they have the commitment.
+func (s *gameServer) commitShuffle() error {
+	s.revealShuffle()
+
+	fair, err := NewFairShuffle()
+	if err != nil {
+		return err
+	}
+	for _, client := range s.clients {
+		if client != nil {
+			client.send("COMMIT", fair.Commitment())
+		}
+	}
+
+	if s.entropy {
+		for _, client := range s.clients {
+			if client == nil || client.send("ENTROPY") != nil {
+				continue
+			}
+			// a client that leaves is taken over by its controller at its next
+			// prompt, and entropy that can't be used is left out
+			if entropy, err := client.readLine(); err == nil {
+				fair.AddEntropy(strings.ToLower(entropy))
+			}
+		}
+	}
+
+	s.game.Deck.CommitShuffle(fair)
+	s.fair = fair
+	return nil
+}
+
+// revealShuffle sends the key and entropy of the last shuffle to the clients
+func (s *gameServer) revealShuffle() {
+	if s.fair == nil {
+		return
+	}
+	for _, client := range s.clients {
+		if client != nil {
+			client.send("REVEAL", s.fair.Reveal())
+		}
+	}
+	s.fair = nil
+}
+
+func (s *gameServer) close() {
+	for _, client := range s.clients {
+		if client != nil {
+			client.conn.Close()
+		}
+	}
+}
+
+// remoteClient is the server's end of a connection to a player
+type remoteClient struct {
+	conn     net.Conn
+	reader   *bufio.Reader
+	lastView string // the last view sent, so unchanged views aren't sent again
+}
+
+func newRemoteClient(conn net.Conn) *remoteClient {
+	client := remoteClient{}
+	client.conn = conn
+	client.reader = bufio.NewReader(conn)
+	return &client
+}
+
+// readHello reads the name the client sent when it connected
+func (c *remoteClient) readHello() (string, error) {
+	line, err := c.readLine()
+	if err != nil {
+		return "", err
+	}
+	name, ok := strings.CutPrefix(line, "HELLO ")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return "", fmt.Errorf("expected HELLO from client but got %q", line)
+	}
+	return name, nil
+}
+
+func (c *remoteClient) send(kind string, args ...string) error {
+	line := strings.Join(append([]string{kind}, args...), " ")
+	_, err := fmt.Fprintf(c.conn, "%s\n", line)
+	return err
+}
+
+func (c *remoteClient) readLine() (string, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// RemoteController asks a player connected over the network for decisions. If
+// the player disconnects the computer takes over their seat.
+type RemoteController struct {
+	client       *remoteClient
+	disconnected bool
+	fallback     PlayerController
+}
+
+func NewRemoteController(client *remoteClient) *RemoteController {
+	controller := RemoteController{}
+	controller.client = client
+	controller.fallback = NewRuleBotController()
+	return &controller
+}
+
+func (rc *RemoteController) OrderUp(player *Player, game *Game) bool {
+	answer, ok := rc.ask(player, game, "ORDER", "Order it up or pass? (o/p)", func(answer string) bool {
+		return answer == "o" || answer == "p"
+	})
+	if !ok {
+		return rc.fallback.OrderUp(player, game)
+	}
+	return answer == "o"
+}
+
+func (rc *RemoteController) PickSuite(player *Player, game *Game, mustPick bool) Suite {
+	kind := "SUITE"
+	text := "Pick a suite or pass (h/d/c/s/n)"
+	if mustPick {
+		kind = "MUSTSUITE"
+		text = "Pick a suite (h/d/c/s)"
+	}
+
+	answer, ok := rc.ask(player, game, kind, text, func(answer string) bool {
+		return (answer == "n" && !mustPick) || isValidSuite(game.TurnedCard.suite, answer)
+	})
+	if !ok {
+		return rc.fallback.PickSuite(player, game, mustPick)
+	}
+	return SuiteFromChar(answer)
+}
+
+func (rc *RemoteController) GoAlone(player *Player, game *Game) bool {
+	answer, ok := rc.ask(player, game, "ALONE", "Do you want to go alone? (y/n)", func(answer string) bool {
+		return answer == "y" || answer == "n"
+	})
+	if !ok {
+		return rc.fallback.GoAlone(player, game)
+	}
+	return answer == "y"
+}
+
+func (rc *RemoteController) Discard(player *Player, game *Game) *Card {
+	card, ok := rc.askCard(player, game, "DISCARD", "Pick a card to discard", func(card *Card) bool {
+		return !game.Rules.DealerMustPickUp || card != game.TurnedCard
+	})
+	if !ok {
+		return rc.fallback.Discard(player, game)
+	}
+	return card
+}
+
+func (rc *RemoteController) PlayCard(player *Player, game *Game) *Card {
+	card, ok := rc.askCard(player, game, "PLAY", "Pick a card", func(card *Card) bool {
+		return IsCardPlayable(card, player.hand, game.Trump, game.Trick.LeadCard())
+	})
+	if !ok {
+		return rc.fallback.PlayCard(player, game)
+	}
+	return card
+}
+
+// askCard asks the player for the index of a card in their hand until they pick
+// one the rules allow
+func (rc *RemoteController) askCard(player *Player, game *Game, kind string, text string, allowed func(*Card) bool) (*Card, bool) {
+	answer, ok := rc.ask(player, game, kind, text, func(answer string) bool {
+		index, err := strconv.Atoi(answer)
+		return err == nil && index >= 0 && index < len(player.hand) && allowed(player.hand[index])
+	})
+	if !ok {
+		return nil, false
+	}
+	index, _ := strconv.Atoi(answer)
+	return player.hand[index], true
+}
+
+// ask prompts the player until they give a valid answer. It returns false if the
+// player is no longer connected.
+func (rc *RemoteController) ask(player *Player, game *Game, kind string, text string, valid func(string) bool) (string, bool) {
+	if rc.disconnected {
+		return "", false
+	}
+
+	for {
+		err := rc.client.send("PROMPT", kind, text)
+		var answer string
+		if err == nil {
+			answer, err = rc.client.readLine()
+		}
+		if err != nil {
+			rc.disconnected = true
+			game.Log("%s disconnected. The computer will play for them.", player.name)
+			return "", false
+		}
+
+		answer = strings.ToLower(answer)
+		if valid(answer) {
+			return answer, true
+		}
+		rc.client.send("INVALID")
+	}
+}
@@ -0,0 +1,215 @@
+package game
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// scriptedClient joins a game and answers every prompt without knowing the
+// rules, trying another answer each time it's asked again. It returns the
+// messages it was sent.
+func scriptedClient(address string, name string, leaveAfter string) ([]string, error) {
+	conn, err := net.Dial("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	fmt.Fprintf(conn, "HELLO %s\n", name)
+	messages := make([]string, 0)
+	handSize := 5
+	tries := 0
+	suites := []string{"h", "d", "c", "s"}
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 4096), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		messages = append(messages, line)
+		kind, args, _ := strings.Cut(line, " ")
+		if kind == leaveAfter {
+			return messages, nil
+		}
+
+		switch kind {
+		case "VIEW":
+			view := PlayerView{}
+			if err := json.Unmarshal([]byte(args), &view); err != nil {
+				return nil, err
+			}
+			handSize = len(view.Hand)
+		case "PROMPT":
+			tries++
+			answer := "n"
+			switch strings.Fields(args)[0] {
+			case "ORDER":
+				answer = "p"
+			case "MUSTSUITE":
+				answer = suites[tries%len(suites)]
+			case "DISCARD":
+				answer = "0"
+			case "PLAY":
+				answer = fmt.Sprint(tries % handSize)
+			}
+			fmt.Fprintf(conn, "%s\n", answer)
+		case "ENTROPY":
+			fmt.Fprintf(conn, "%x\n", name)
+		case "END":
+			return messages, nil
+		}
+	}
+	return messages, scanner.Err()
+}
+
+func startTestServer(t *testing.T, bots []int) (string, chan *Game) {
+	config := ServerConfig{}
+	config.Bots = bots
+	return startTestServerWith(t, config)
+}
+
+func startTestServerWith(t *testing.T, config ServerConfig) (string, chan *Game) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	config.BotStrategy = "rule"
+	config.Rules = DefaultRuleSet()
+
+	done := make(chan *Game, 1)
+	go func() {
+		defer listener.Close()
+		game, err := serveGame(listener, config)
+		assert.NoError(t, err)
+		done <- game
+	}()
+	return listener.Addr().String(), done
+}
+
+func TestServerPlaysRemoteGame(t *testing.T) {
+	address, done := startTestServer(t, []int{1, 3})
+	results := make(chan []string, 2)
+	for _, name := range []string{"Alice", "Carol"} {
+		go func(name string) {
+			messages, err := scriptedClient(address, name, "")
+			assert.NoError(t, err)
+			results <- messages
+		}(name)
+	}
+
+	game := <-done
+	assert.Equal(t, EndGame, game.StateMachine.CurrentState.GetName())
+	for i := 0; i < 2; i++ {
+		messages := <-results
+		assert.Equal(t, "END", messages[len(messages)-1])
+		assert.True(t, strings.HasPrefix(messages[0], "WELCOME "))
+	}
+	assert.ElementsMatch(t, []string{"Alice", "Carol"}, []string{game.Players[0].name, game.Players[2].name})
+}
+
+func TestServerOnlySendsOwnHand(t *testing.T) {
+	address, done := startTestServer(t, []int{1, 2, 3})
+	messages, err := scriptedClient(address, "Alice", "")
+	assert.NoError(t, err)
+	<-done
+
+	views := 0
+	for _, message := range messages {
+		args, ok := strings.CutPrefix(message, "VIEW ")
+		if !ok {
+			continue
+		}
+		views++
+		view := PlayerView{}
+		assert.NoError(t, json.Unmarshal([]byte(args), &view))
+		assert.Equal(t, 0, view.Seat)
+		assert.Equal(t, view.HandSizes[0], len(view.Hand))
+	}
+	assert.Greater(t, views, 0)
+}
+
+func TestServerBotTakesOverDisconnectedSeat(t *testing.T) {
+	address, done := startTestServer(t, []int{1, 2, 3})
+	_, err := scriptedClient(address, "Alice", "PROMPT")
+	assert.NoError(t, err)
+
+	game := <-done
+	assert.Equal(t, EndGame, game.StateMachine.CurrentState.GetName())
+	assert.Contains(t, game.logs, "Alice disconnected. The computer will play for them.")
+}
+
+func TestServerRevealsEveryShuffle(t *testing.T) {
+	config := ServerConfig{}
+	config.Bots = []int{1, 2, 3}
+	config.Entropy = true
+	address, done := startTestServerWith(t, config)
+	messages, err := scriptedClient(address, "Alice", "")
+	assert.NoError(t, err)
+	game := <-done
+
+	shuffles := make([]Event, 0)
+	for _, event := range game.StateMachine.Events {
+		if event.Type == ShuffleEvent {
+			shuffles = append(shuffles, event)
+		}
+	}
+
+	// every shuffle is committed to before it's revealed, and the reveal gives
+	// the order the deck was shuffled to with the client's entropy mixed in
+	commitment := ""
+	reveals := 0
+	for _, message := range messages {
+		kind, args, _ := strings.Cut(message, " ")
+		switch kind {
+		case "COMMIT":
+			commitment = args
+		case "REVEAL":
+			assert.True(t, strings.HasPrefix(args, commitment+" "))
+			assert.Contains(t, strings.Fields(args)[2:], fmt.Sprintf("%x", "Alice"))
+			deck, err := VerifyReveal(args)
+			assert.NoError(t, err)
+			if assert.Less(t, reveals, len(shuffles)) {
+				assert.Equal(t, shuffles[reveals].Cards, deck)
+			}
+			reveals++
+		}
+	}
+	assert.Equal(t, len(shuffles), reveals)
+}
+
+func TestRemoteControllerRejectsUnplayableCard(t *testing.T) {
+	server, conn := net.Pipe()
+	defer server.Close()
+	defer conn.Close()
+	controller := NewRemoteController(newRemoteClient(server))
+
+	game := NewGame(DefaultRuleSet())
+	game.Trump = HEART
+	game.PlayCard(&Card{rank: ACE, suite: SPADE})
+	player := game.Players[1]
+	player.GiveCards([]*Card{{rank: NINE, suite: CLUB}, {rank: TEN, suite: SPADE}})
+
+	played := make(chan *Card)
+	go func() {
+		played <- controller.PlayCard(player, &game)
+	}()
+
+	// the club doesn't follow suite so the player is asked again
+	reader := bufio.NewReader(conn)
+	answers := []string{"0", "1"}
+	messages := make([]string, 0)
+	for len(answers) > 0 {
+		line, err := reader.ReadString('\n')
+		assert.NoError(t, err)
+		messages = append(messages, strings.TrimSpace(line))
+		if strings.HasPrefix(line, "PROMPT") {
+			fmt.Fprintf(conn, "%s\n", answers[0])
+			answers = answers[1:]
+		}
+	}
+	assert.Equal(t, player.hand[1], <-played)
+	assert.Equal(t, []string{"PROMPT PLAY Pick a card", "INVALID", "PROMPT PLAY Pick a card"}, messages)
+}
@@ -0,0 +1,200 @@
+package game
+
+// SimState is a lightweight copy of a hand that is being played. Cards are held by
+// value so a SimState can be cloned and stepped thousands of times without
+// touching the Game, the display or stdin.
+type SimState struct {
+	Hands       [][]Card // the cards held by each seat
+	Trump       Suite
+	Maker       int    // the seat that called trump
+	Alone       bool   // true if the maker is going alone
+	Turn        int    // the seat that plays next
+	Trick       []Play // the plays made so far in the current trick
+	TrickNumber int
+	Tricks      []int // tricks taken by each team. Seat s plays for team s % len(Tricks)
+	Rules       RuleSet
+}
+
+// newSimState creates an empty state with a hand for every seat and a trick
+// count for every team the rules call for
+func newSimState(rules RuleSet) SimState {
+	state := SimState{}
+	state.Rules = rules
+	state.Hands = make([][]Card, rules.PlayerCount())
+	state.Tricks = make([]int, len(newTeams(rules)))
+	return state
+}
+
+// NewSimState copies the hand being played in game. It should only be used once
+// trump has been picked.
+func NewSimState(game *Game) SimState {
+	state := newSimState(game.Rules)
+	state.Trump = game.Trump
+	state.Maker = game.OrderedPlayerIndex
+	state.Alone = game.AlonePlayerIndex != -1
+	state.Turn = game.PlayerIndex
+
+	for i, player := range game.Players {
+		state.Hands[i] = cardValues(player.hand)
+	}
+	for _, team := range game.Teams {
+		state.Tricks[team.Index] = team.Tricks
+		state.TrickNumber += team.Tricks
+	}
+
+	state.Trick = append([]Play{}, game.Trick.Plays...)
+	return state
+}
+
+// Clone returns a copy of the state that can be stepped independently
+func (s *SimState) Clone() SimState {
+	clone := *s
+	clone.Hands = make([][]Card, len(s.Hands))
+	for i := range s.Hands {
+		clone.Hands[i] = append([]Card{}, s.Hands[i]...)
+	}
+	clone.Trick = append([]Play{}, s.Trick...)
+	clone.Tricks = append([]int{}, s.Tricks...)
+	return clone
+}
+
+// LegalCards returns the cards the player whose turn it is can play
+func (s *SimState) LegalCards() []Card {
+	cards := cardPointers(s.Hands[s.Turn])
+
+	var leadCard *Card = nil
+	if len(s.Trick) > 0 {
+		leadCard = &s.Trick[0].Card
+	}
+
+	return cardValues(GetPlayableCards(cards, s.Trump, leadCard))
+}
+
+// Play plays the card from the hand of the player whose turn it is. When the
+// trick is complete the winner takes it and leads the next one.
+func (s *SimState) Play(card Card) {
+	hand := s.Hands[s.Turn]
+	for i, c := range hand {
+		if c == card {
+			s.Hands[s.Turn] = append(hand[:i:i], hand[i+1:]...)
+			break
+		}
+	}
+	s.Trick = append(s.Trick, Play{Seat: s.Turn, Card: card, Trick: s.TrickNumber})
+
+	if len(s.Trick) < s.playerCount() {
+		s.Turn = (s.Turn + 1) % len(s.Hands)
+		if s.IsSittingOut(s.Turn) {
+			s.Turn = (s.Turn + 1) % len(s.Hands)
+		}
+		return
+	}
+
+	winner := s.TrickWinner()
+	s.Tricks[s.teamOf(winner)] += 1
+	s.Turn = winner
+	s.Trick = nil
+	s.TrickNumber += 1
+}
+
+// TrickWinner returns the seat that is winning the current trick
+func (s *SimState) TrickWinner() int {
+	trick := Trick{Number: s.TrickNumber, Plays: s.Trick}
+	return trick.Winner(s.Trump).Seat
+}
+
+// IsOver returns true once every trick of the hand has been played
+func (s *SimState) IsOver() bool {
+	if len(s.Trick) > 0 {
+		return false
+	}
+	for i, hand := range s.Hands {
+		if len(hand) > 0 && !s.IsSittingOut(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSittingOut returns true if the seat's partner is going alone
+func (s *SimState) IsSittingOut(seat int) bool {
+	return s.Alone && seat == s.partnerOf(s.Maker)
+}
+
+func (s *SimState) playerCount() int {
+	if s.Alone {
+		return len(s.Hands) - 1
+	}
+	return len(s.Hands)
+}
+
+// teamOf returns the index of the team the seat plays for
+func (s *SimState) teamOf(seat int) int {
+	return seat % len(s.Tricks)
+}
+
+// partnerOf returns the seat across the table from seat, or -1 in cutthroat
+// where every seat is a team of one
+func (s *SimState) partnerOf(seat int) int {
+	if len(s.Tricks) == len(s.Hands) {
+		return -1
+	}
+	return (seat + 2) % 4
+}
+
+// Score returns the points the team of seat earned in a finished hand, less the
+// most points any other team earned
+func (s *SimState) Score(seat int) int {
+	makers := s.teamOf(s.Maker)
+	points := make([]int, len(s.Tricks))
+
+	if s.Tricks[makers] == 5 && s.Alone {
+		points[makers] = s.Rules.LonerPoints
+	} else if s.Tricks[makers] == 5 {
+		points[makers] = s.Rules.MarchPoints()
+	} else if s.Tricks[makers] >= 3 {
+		points[makers] = 1
+	} else {
+		for team := range points {
+			if team != makers {
+				points[team] = s.Rules.EuchrePoints
+			}
+		}
+	}
+
+	team := s.teamOf(seat)
+	best := 0
+	for other, p := range points {
+		if other != team && p > best {
+			best = p
+		}
+	}
+	return points[team] - best
+}
+
+// PlayOut finishes the hand with every seat played by the rule bot
+func (s *SimState) PlayOut() {
+	for !s.IsOver() {
+		s.Play(s.ruleBotCard())
+	}
+}
+
+// ruleBotCard picks the card the rule bot would play from the current hand
+func (s *SimState) ruleBotCard() Card {
+	cards := cardPointers(s.LegalCards())
+
+	if len(s.Trick) == 0 {
+		isMaker := s.teamOf(s.Maker) == s.teamOf(s.Turn)
+		return *chooseLeadCard(cards, s.Trump, isMaker)
+	}
+	return *chooseFollowCard(cards, &Trick{Number: s.TrickNumber, Plays: s.Trick}, s.partnerOf(s.Turn), s.Trump)
+}
+
+// allCards returns every card in a euchre deck
+func allCards() []Card {
+	cards := make([]Card, 0, 24)
+	for i := 0; i < 24; i++ {
+		cards = append(cards, Card{rank: IntToRank(i % 6), suite: IntToSuite(i / 6)})
+	}
+	return cards
+}
@@ -0,0 +1,232 @@
+package game
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"runtime"
+	"sync"
+)
+
+// SimulationConfig holds the options for Simulate
+type SimulationConfig struct {
+	Games      int
+	Strategies []string // the bot strategy of each team, three of them in cutthroat. See newSimulationBot
+	Rules      RuleSet
+	Seed       int64 // master seed every game's seed is derived from. One is picked from the time if 0
+	Workers    int   // games played at once. Defaults to the number of CPUs
+}
+
+// SimulationResult adds up how each team did over a simulation. Teams are
+// numbered like Game.Teams, so team 0 is seats 0 and 2, or just seat 0 in
+// cutthroat.
+type SimulationResult struct {
+	Strategies    []string
+	Games         int
+	Wins          []int
+	Hands         int
+	Points        []int // points earned over every hand
+	PointsSquared []int // sum of the square of the points earned each hand, for the variance
+	Made          []int // hands the team made trump
+	Euchred       []int // hands the team made trump and got euchred
+}
+
+func newSimulationResult(strategies []string) SimulationResult {
+	result := SimulationResult{Strategies: strategies}
+	result.Wins = make([]int, len(strategies))
+	result.Points = make([]int, len(strategies))
+	result.PointsSquared = make([]int, len(strategies))
+	result.Made = make([]int, len(strategies))
+	result.Euchred = make([]int, len(strategies))
+	return result
+}
+
+// Simulate plays complete games between bots on several goroutines at once.
+// Every game is run by its own engine with its own seed, so the result only
+// depends on the config.
+func Simulate(config SimulationConfig) (SimulationResult, error) {
+	result := newSimulationResult(config.Strategies)
+	if teams := len(newTeams(config.Rules)); len(config.Strategies) != teams {
+		return result, fmt.Errorf("%d strategies for %d teams", len(config.Strategies), teams)
+	}
+	for _, strategy := range config.Strategies {
+		if _, err := newSimulationBot(strategy, 0); err != nil {
+			return result, err
+		}
+	}
+
+	workers := config.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	master := config.Seed
+	if master == 0 {
+		master = NewSeed()
+	}
+
+	seeds := make(chan int64)
+	go func() {
+		seed := master
+		for i := 0; i < config.Games; i++ {
+			seed = NextSeed(seed)
+			seeds <- seed
+		}
+		close(seeds)
+	}()
+
+	var lock sync.Mutex
+	var wait sync.WaitGroup
+	var firstErr error
+	for i := 0; i < workers; i++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			for seed := range seeds {
+				game, err := simulateGame(config, seed)
+				lock.Lock()
+				if err == nil {
+					result.add(game)
+				} else if firstErr == nil {
+					firstErr = err
+				}
+				lock.Unlock()
+			}
+		}()
+	}
+	wait.Wait()
+	return result, firstErr
+}
+
+// simulateGame plays one game to the end
+func simulateGame(config SimulationConfig, seed int64) (*Game, error) {
+	engine := EngineConfig{}
+	engine.Rules = config.Rules
+	engine.Seed = seed
+	engine.Controllers = make([]PlayerController, config.Rules.PlayerCount())
+	for _, team := range newTeams(config.Rules) {
+		for _, seat := range team.Members {
+			bot, err := newSimulationBot(config.Strategies[team.Index], seed+int64(seat))
+			if err != nil {
+				return nil, err
+			}
+			engine.Controllers[seat] = bot
+		}
+	}
+
+	game, err := NewEngine(engine)
+	if err != nil {
+		return nil, err
+	}
+	game.PlayToEnd()
+	return game, nil
+}
+
+// newSimulationBot creates a bot like NewBotController does, but seeded so a
+// simulation can be repeated. The Monte Carlo bot samples a fixed number of
+// deals so the result doesn't depend on how fast the computer is.
+func newSimulationBot(strategy string, seed int64) (PlayerController, error) {
+	switch strategy {
+	case "rule":
+		return NewRuleBotController(), nil
+	case "montecarlo":
+		return NewMonteCarloController(0, 0, seed), nil
+	}
+	return nil, fmt.Errorf("unknown bot strategy %q", strategy)
+}
+
+// add counts the hands and the winner of a finished game. A hand ends with its
+// fifth trick, and is counted then because a hand worth no points to anyone has
+// no PointsEvent.
+func (r *SimulationResult) add(game *Game) {
+	r.Games++
+	r.Wins[game.WinningTeam().Index]++
+
+	maker := -1
+	tricks := 0
+	makerTricks := 0
+	for _, event := range game.StateMachine.Events {
+		switch event.Type {
+		case OrderUpEvent, PickSuiteEvent:
+			maker = game.TeamOf(event.Player).Index
+		case TrickWonEvent:
+			tricks++
+			if game.TeamOf(event.Player).Index == maker {
+				makerTricks++
+			}
+			if tricks == 5 {
+				r.Hands++
+				r.Made[maker]++
+				if makerTricks < 3 {
+					r.Euchred[maker]++
+				}
+				maker, tricks, makerTricks = -1, 0, 0
+			}
+		case PointsEvent:
+			r.Points[event.Team] += event.Points
+			r.PointsSquared[event.Team] += event.Points * event.Points
+		}
+	}
+}
+
+// WinRate returns the share of games the team won and its 95% confidence interval
+func (r *SimulationResult) WinRate(team int) (rate, low, high float64) {
+	return proportion(r.Wins[team], r.Games)
+}
+
+// EuchreRate returns the share of the hands the team made trump that they were
+// euchred on, and its 95% confidence interval
+func (r *SimulationResult) EuchreRate(team int) (rate, low, high float64) {
+	return proportion(r.Euchred[team], r.Made[team])
+}
+
+// PointsPerHand returns the average points the team earned each hand and its 95%
+// confidence interval
+func (r *SimulationResult) PointsPerHand(team int) (mean, low, high float64) {
+	if r.Hands == 0 {
+		return 0, 0, 0
+	}
+	n := float64(r.Hands)
+	mean = float64(r.Points[team]) / n
+	variance := 0.0
+	if r.Hands > 1 {
+		variance = (float64(r.PointsSquared[team]) - n*mean*mean) / (n - 1)
+	}
+	margin := z95 * math.Sqrt(math.Max(variance, 0)/n)
+	return mean, mean - margin, mean + margin
+}
+
+// the z score of a 95% confidence interval
+const z95 = 1.96
+
+// proportion returns successes/trials and the Wilson score interval around it,
+// which unlike the usual normal interval behaves near 0% and 100%
+func proportion(successes int, trials int) (rate, low, high float64) {
+	if trials == 0 {
+		return 0, 0, 0
+	}
+	n := float64(trials)
+	rate = float64(successes) / n
+	z2 := z95 * z95
+	center := (rate + z2/(2*n)) / (1 + z2/n)
+	margin := z95 * math.Sqrt(rate*(1-rate)/n+z2/(4*n*n)) / (1 + z2/n)
+	return rate, center - margin, center + margin
+}
+
+// Report writes the result for people to read
+func (r *SimulationResult) Report(w io.Writer) {
+	fmt.Fprintf(w, "Played %d games and %d hands\n", r.Games, r.Hands)
+	for team := range r.Strategies {
+		fmt.Fprintf(w, "\nTeam %d (%s)\n", team+1, r.Strategies[team])
+
+		rate, low, high := r.WinRate(team)
+		fmt.Fprintf(w, "  Win rate:        %5.1f%%  (95%% CI %.1f%% to %.1f%%), %d of %d games\n",
+			rate*100, low*100, high*100, r.Wins[team], r.Games)
+
+		mean, low, high := r.PointsPerHand(team)
+		fmt.Fprintf(w, "  Points per hand: %6.3f  (95%% CI %.3f to %.3f)\n", mean, low, high)
+
+		rate, low, high = r.EuchreRate(team)
+		fmt.Fprintf(w, "  Euchre rate:     %5.1f%%  (95%% CI %.1f%% to %.1f%%), %d of %d hands they made trump\n",
+			rate*100, low*100, high*100, r.Euchred[team], r.Made[team])
+	}
+}
@@ -0,0 +1,97 @@
+package game
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulateIsRepeatable(t *testing.T) {
+	config := SimulationConfig{}
+	config.Games = 20
+	config.Strategies = []string{"rule", "rule"}
+	config.Rules = DefaultRuleSet()
+	config.Seed = 7
+	config.Workers = 4
+
+	result, err := Simulate(config)
+	assert.NoError(t, err)
+	assert.Equal(t, 20, result.Games)
+	assert.Equal(t, 20, result.Wins[0]+result.Wins[1])
+	assert.Equal(t, result.Hands, result.Made[0]+result.Made[1])
+	assert.LessOrEqual(t, result.Euchred[0], result.Made[0])
+
+	// the games played don't depend on how many are played at once
+	config.Workers = 1
+	again, err := Simulate(config)
+	assert.NoError(t, err)
+	assert.Equal(t, result, again)
+
+	var report bytes.Buffer
+	result.Report(&report)
+	assert.Contains(t, report.String(), "Played 20 games")
+	assert.Contains(t, report.String(), "Team 2 (rule)")
+}
+
+func TestSimulateCountsScorelessHands(t *testing.T) {
+	config := SimulationConfig{}
+	config.Games = 10
+	config.Strategies = []string{"rule", "rule"}
+	config.Rules = DefaultRuleSet()
+	config.Rules.EuchrePoints = 0
+	config.Seed = 7
+
+	result, err := Simulate(config)
+	assert.NoError(t, err)
+	assert.Equal(t, result.Hands, result.Made[0]+result.Made[1])
+	assert.Greater(t, result.Euchred[0]+result.Euchred[1], 0, "expected hands worth nothing to be counted")
+}
+
+func TestSimulateCutthroat(t *testing.T) {
+	config := SimulationConfig{}
+	config.Games = 4
+	config.Strategies = []string{"montecarlo", "rule", "rule"}
+	config.Rules = DefaultRuleSet()
+	config.Rules.Cutthroat = true
+	config.Rules.TargetScore = 5
+	config.Seed = 7
+
+	result, err := Simulate(config)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, result.Wins[0]+result.Wins[1]+result.Wins[2])
+	assert.Equal(t, result.Hands, result.Made[0]+result.Made[1]+result.Made[2])
+
+	var report bytes.Buffer
+	result.Report(&report)
+	assert.Contains(t, report.String(), "Team 3 (rule)")
+}
+
+func TestSimulateRejectsUnknownStrategy(t *testing.T) {
+	config := SimulationConfig{Games: 1, Strategies: []string{"rule", "random"}}
+	_, err := Simulate(config)
+	assert.Error(t, err)
+}
+
+func TestSimulateNeedsStrategyForEveryTeam(t *testing.T) {
+	config := SimulationConfig{Games: 1, Strategies: []string{"rule", "rule"}}
+	config.Rules.Cutthroat = true
+	_, err := Simulate(config)
+	assert.Error(t, err)
+}
+
+func TestProportionInterval(t *testing.T) {
+	rate, low, high := proportion(50, 100)
+	assert.Equal(t, 0.5, rate)
+	assert.InDelta(t, 0.404, low, 0.001)
+	assert.InDelta(t, 0.596, high, 0.001)
+
+	// the interval stays between 0 and 1
+	rate, low, high = proportion(0, 10)
+	assert.Equal(t, 0.0, rate)
+	assert.InDelta(t, 0.0, low, 1e-9)
+	assert.Less(t, high, 0.5)
+
+	rate, _, _ = proportion(0, 0)
+	assert.Equal(t, 0.0, rate)
+}
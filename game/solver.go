@@ -0,0 +1,211 @@
+package game
+
+import "sort"
+
+// DoubleDummyResult is the outcome of a hand when every seat can see every card
+// and plays perfectly
+type DoubleDummyResult struct {
+	Tricks   [2]int // tricks each team ends the hand with, including ones already taken
+	BestCard Card   // the best card for the seat whose turn it is
+}
+
+// DoubleDummySolver finds the perfect play for a four handed hand where every
+// hand is known. Positions at the start of each trick are remembered, so a
+// solver should be reused while analysing the same deal.
+type DoubleDummySolver struct {
+	table map[solverKey]solverBounds
+}
+
+// solverKey identifies a position at the start of a trick
+type solverKey struct {
+	hands [4]uint32 // one bit per card, see cardBit
+	turn  int
+	trump Suite
+}
+
+// solverBounds are the fewest and most tricks team 0 can take from a position
+type solverBounds struct {
+	lower int
+	upper int
+}
+
+func NewDoubleDummySolver() *DoubleDummySolver {
+	solver := DoubleDummySolver{}
+	solver.table = make(map[solverKey]solverBounds)
+	return &solver
+}
+
+// NewDoubleDummyState creates the state for a dealt hand that is about to be played
+func NewDoubleDummyState(hands [4][]Card, trump Suite, maker int, leader int) SimState {
+	state := newSimState(DefaultRuleSet())
+	for i := range hands {
+		state.Hands[i] = append([]Card{}, hands[i]...)
+	}
+	state.Trump = trump
+	state.Maker = maker
+	state.Turn = leader
+	return state
+}
+
+// Solve returns the number of tricks each team takes with perfect play from the
+// state and the best card for the seat whose turn it is
+func (solver *DoubleDummySolver) Solve(state SimState) DoubleDummyResult {
+	result := DoubleDummyResult{Tricks: [2]int{state.Tricks[0], state.Tricks[1]}}
+	if state.IsOver() {
+		return result
+	}
+
+	team := state.Turn % 2
+	bestTricks := -1
+	for _, c := range solver.orderedCards(&state) {
+		tricks := solver.playValue(&state, c, -1, 6)
+		if team == 1 {
+			tricks = state.remainingTricks() - tricks
+		}
+		if tricks > bestTricks {
+			bestTricks = tricks
+			result.BestCard = c
+		}
+	}
+
+	result.Tricks[team] += bestTricks
+	result.Tricks[1-team] += state.remainingTricks() - bestTricks
+	return result
+}
+
+// OptimalLine returns every play from the state to the end of the hand with
+// each seat playing perfectly
+func (solver *DoubleDummySolver) OptimalLine(state SimState) []Play {
+	state = state.Clone()
+	line := make([]Play, 0)
+	for !state.IsOver() {
+		c := solver.Solve(state).BestCard
+		line = append(line, Play{Seat: state.Turn, Card: c, Trick: state.TrickNumber})
+		state.Play(c)
+	}
+	return line
+}
+
+// search returns the number of tricks team 0 takes from the rest of the hand.
+// The result is exact when it falls between alpha and beta, otherwise it is only
+// a bound.
+func (solver *DoubleDummySolver) search(state *SimState, alpha int, beta int) int {
+	if state.IsOver() {
+		return 0
+	}
+
+	var key solverKey
+	startOfTrick := len(state.Trick) == 0
+	if startOfTrick {
+		key = state.solverKey()
+		bounds, ok := solver.table[key]
+		if !ok {
+			bounds = solverBounds{lower: 0, upper: state.remainingTricks()}
+		}
+		if bounds.lower >= beta || bounds.lower == bounds.upper {
+			return bounds.lower
+		}
+		if bounds.upper <= alpha {
+			return bounds.upper
+		}
+		alpha = maxInt(alpha, bounds.lower)
+		beta = minInt(beta, bounds.upper)
+	}
+
+	maximizing := state.Turn%2 == 0
+	best := 6
+	if maximizing {
+		best = -1
+	}
+
+	a, b := alpha, beta
+	for _, c := range solver.orderedCards(state) {
+		value := solver.playValue(state, c, a, b)
+		if maximizing {
+			best = maxInt(best, value)
+			a = maxInt(a, best)
+		} else {
+			best = minInt(best, value)
+			b = minInt(b, best)
+		}
+		if a >= b {
+			break
+		}
+	}
+
+	if startOfTrick {
+		bounds, ok := solver.table[key]
+		if !ok {
+			bounds = solverBounds{lower: 0, upper: state.remainingTricks()}
+		}
+		if best <= alpha {
+			bounds.upper = minInt(bounds.upper, best)
+		} else if best >= beta {
+			bounds.lower = maxInt(bounds.lower, best)
+		} else {
+			bounds = solverBounds{lower: best, upper: best}
+		}
+		solver.table[key] = bounds
+	}
+	return best
+}
+
+// playValue returns the number of tricks team 0 takes from the rest of the hand
+// after the card is played
+func (solver *DoubleDummySolver) playValue(state *SimState, c Card, alpha int, beta int) int {
+	next := state.Clone()
+	before := next.Tricks[0]
+	next.Play(c)
+	won := next.Tricks[0] - before
+	return won + solver.search(&next, alpha-won, beta-won)
+}
+
+// orderedCards returns the legal cards with the strongest first, which lets the
+// search prune more of the hand
+func (solver *DoubleDummySolver) orderedCards(state *SimState) []Card {
+	lead := state.Trump
+	if len(state.Trick) > 0 {
+		lead = EffectiveSuit(state.Trick[0].Card, state.Trump)
+	}
+
+	cards := state.LegalCards()
+	sort.Slice(cards, func(i, j int) bool {
+		return cardOrder(&cards[i], state.Trump, lead) > cardOrder(&cards[j], state.Trump, lead)
+	})
+	return cards
+}
+
+func (s *SimState) solverKey() solverKey {
+	key := solverKey{turn: s.Turn, trump: s.Trump}
+	for i, hand := range s.Hands {
+		for _, c := range hand {
+			key.hands[i] |= cardBit(c)
+		}
+	}
+	return key
+}
+
+// remainingTricks returns the number of tricks left to be won in the hand
+func (s *SimState) remainingTricks() int {
+	// the seat whose turn it is hasn't played in the current trick yet
+	return len(s.Hands[s.Turn])
+}
+
+// cardBit returns a bit that is unique to the card
+func cardBit(c Card) uint32 {
+	return 1 << (uint(c.suite-DIAMOND)*6 + uint(c.rank))
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,81 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// bruteForceTricks returns the tricks team 0 takes from the rest of the hand by
+// searching every line of play
+func bruteForceTricks(state SimState) int {
+	if state.IsOver() {
+		return 0
+	}
+	best := -1
+	for _, c := range state.LegalCards() {
+		next := state.Clone()
+		next.Play(c)
+		tricks := next.Tricks[0] - state.Tricks[0] + bruteForceTricks(next)
+		if best == -1 || (state.Turn%2 == 0 && tricks > best) || (state.Turn%2 == 1 && tricks < best) {
+			best = tricks
+		}
+	}
+	return best
+}
+
+func TestSolverAllTrump(t *testing.T) {
+	hands := [4][]Card{
+		{{rank: JACK, suite: HEART}, {rank: JACK, suite: DIAMOND}, {rank: ACE, suite: HEART}, {rank: KING, suite: HEART}, {rank: QUEEN, suite: HEART}},
+		{{rank: NINE, suite: CLUB}, {rank: TEN, suite: CLUB}, {rank: JACK, suite: CLUB}, {rank: QUEEN, suite: CLUB}, {rank: KING, suite: CLUB}},
+		{{rank: NINE, suite: SPADE}, {rank: TEN, suite: SPADE}, {rank: JACK, suite: SPADE}, {rank: QUEEN, suite: SPADE}, {rank: KING, suite: SPADE}},
+		{{rank: ACE, suite: CLUB}, {rank: ACE, suite: SPADE}, {rank: ACE, suite: DIAMOND}, {rank: KING, suite: DIAMOND}, {rank: QUEEN, suite: DIAMOND}},
+	}
+
+	// with the top five trump and the lead, team 0 takes everything
+	solver := NewDoubleDummySolver()
+	result := solver.Solve(NewDoubleDummyState(hands, HEART, 0, 0))
+	assert.Equal(t, [2]int{5, 0}, result.Tricks)
+
+	line := solver.OptimalLine(NewDoubleDummyState(hands, HEART, 0, 0))
+	assert.Len(t, line, 20, "expected every card to be played")
+}
+
+func TestSolverMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		cards := allCards()
+		rng.Shuffle(len(cards), func(i, j int) { cards[i], cards[j] = cards[j], cards[i] })
+
+		var hands [4][]Card
+		for seat := range hands {
+			hands[seat] = cards[seat*3 : seat*3+3]
+		}
+		trump := IntToSuite(rng.Intn(4))
+		state := NewDoubleDummyState(hands, trump, 0, rng.Intn(4))
+
+		expected := bruteForceTricks(state)
+		result := NewDoubleDummySolver().Solve(state)
+		assert.Equal(t, expected, result.Tricks[0], "solver disagrees with brute force on deal %d", i)
+		assert.Equal(t, 3-expected, result.Tricks[1], "solver disagrees with brute force on deal %d", i)
+
+		// playing the best card must keep the same result
+		next := state.Clone()
+		next.Play(result.BestCard)
+		assert.Equal(t, expected, next.Tricks[0]+bruteForceTricks(next), "best card loses tricks on deal %d", i)
+	}
+}
+
+func TestSolverFullDeal(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	cards := allCards()
+	rng.Shuffle(len(cards), func(i, j int) { cards[i], cards[j] = cards[j], cards[i] })
+
+	var hands [4][]Card
+	for seat := range hands {
+		hands[seat] = cards[seat*5 : seat*5+5]
+	}
+	result := NewDoubleDummySolver().Solve(NewDoubleDummyState(hands, SPADE, 1, 1))
+	assert.Equal(t, 5, result.Tricks[0]+result.Tricks[1], "expected all five tricks to be counted")
+}
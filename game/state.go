@@ -14,6 +14,7 @@ const (
 	DealerPickupTrump   StateName = "DealerPickupTrump"
 	TrumpSelectionTwo   StateName = "TrumpSelectionTwo"
 	ScrewDealer         StateName = "ScrewDealer"
+	GoAlone             StateName = "GoAlone"
 	StartRound          StateName = "StartRound"
 	GetPlayerCard       StateName = "GetPlayerCard"
 	CheckValidCard      StateName = "CheckValidCard"
@@ -26,6 +27,11 @@ const (
 
 type StateMachine struct {
 	CurrentState GameState
+	Events       []Event   // every decision and random outcome so far
+	EventLog     *EventLog // optional log the events are appended to
+	Observer     Observer  // optional observer told about every event and state
+	replay       []Event   // the events being replayed, see Replay
+	replayErr    error     // the first recorded decision that couldn't be replayed
 }
 
 func NewStateMachine() StateMachine {
@@ -42,42 +48,53 @@ func (sm *StateMachine) TransitionState(newStateName StateName) {
 	}
 
 	// create the new state and enter it
-	switch newStateName {
+	sm.CurrentState = NewState(newStateName)
+	if sm.Observer != nil {
+		sm.Observer.OnState(newStateName)
+	}
+}
+
+// NewState creates the state with the given name. It returns nil for an unknown name.
+func NewState(name StateName) GameState {
+	switch name {
 	case InitGame:
-		sm.CurrentState = NewInitState()
+		return NewInitState()
 	case DrawForDealer:
-		sm.CurrentState = NewDrawForDealerState()
+		return NewDrawForDealerState()
 	case ResetDeckAndShuffle:
-		sm.CurrentState = NewResetDeckAndShuffleState()
+		return NewResetDeckAndShuffleState()
 	case DealCards:
-		sm.CurrentState = NewDealCardsState()
+		return NewDealCardsState()
 	case RevealTopCard:
-		sm.CurrentState = NewRevealTopCardState()
+		return NewRevealTopCardState()
 	case TrumpSelectionOne:
-		sm.CurrentState = NewTrumpSelectionOneState()
+		return NewTrumpSelectionOneState()
 	case DealerPickupTrump:
-		sm.CurrentState = NewDealerPickupTrumpState()
+		return NewDealerPickupTrumpState()
 	case TrumpSelectionTwo:
-		sm.CurrentState = NewTrumpSelectionTwoState()
+		return NewTrumpSelectionTwoState()
 	case ScrewDealer:
-		sm.CurrentState = NewScrewDealerState()
+		return NewScrewDealerState()
+	case GoAlone:
+		return NewGoAloneState()
 	case StartRound:
-		sm.CurrentState = NewStartRoundState()
+		return NewStartRoundState()
 	case GetPlayerCard:
-		sm.CurrentState = NewGetPlayerCardState()
+		return NewGetPlayerCardState()
 	case CheckValidCard:
-		sm.CurrentState = NewCheckValidCardState()
+		return NewCheckValidCardState()
 	case PlayCard:
-		sm.CurrentState = NewPlayCardState()
+		return NewPlayCardState()
 	case GetTrickWinner:
-		sm.CurrentState = NewGetTrickWinnerState()
+		return NewGetTrickWinnerState()
 	case GivePoints:
-		sm.CurrentState = NewGivePointsState()
+		return NewGivePointsState()
 	case CheckForWinner:
-		sm.CurrentState = NewCheckForWinnerState()
+		return NewCheckForWinnerState()
 	case EndGame:
-		sm.CurrentState = NewEndGameState()
+		return NewEndGameState()
 	}
+	return nil
 }
 
 func (sm *StateMachine) Step(game *Game) {
@@ -126,8 +143,11 @@ func NewInitState() *InitGameState {
 }
 
 func (state *InitGameState) DoState(game *Game) StateName {
+	// keep a fair shuffle that was committed to before the game started
+	fair := game.Deck.fair
 	game.Deck = InitDeck(game.RandSeed)
-	game.Deck.Shuffle()
+	game.Deck.CommitShuffle(fair)
+	game.shuffleDeck()
 	return DrawForDealer
 }
 
@@ -144,19 +164,20 @@ func NewDrawForDealerState() *DrawForDealerState {
 
 func (state *DrawForDealerState) DoState(game *Game) StateName {
 	// draw for a jack
-	game.PlayedCards = append(game.PlayedCards, game.Deck.pop())
-	lastIndex := len(game.PlayedCards) - 1
+	game.DrawnCards = append(game.DrawnCards, game.Deck.pop())
+	lastIndex := len(game.DrawnCards) - 1
 
 	// print drawn card
-	game.Log("%s was drawn", game.PlayedCards[lastIndex].ToString())
+	game.Log("%s was drawn", game.DrawnCards[lastIndex].ToString())
 
-	if game.PlayedCards[lastIndex].rank == JACK {
+	if game.DrawnCards[lastIndex].rank == JACK {
 		// got trump. Set dealer and continue
 		game.DealerIndex = game.PlayerIndex
-		game.PlayerIndex = (game.DealerIndex + 1) % 4 // first player is next to dealer
+		game.PlayerIndex = game.leftOf(game.DealerIndex) // first player is next to dealer
 		dealer := game.Players[game.DealerIndex]
 		game.Log("%s is dealer", dealer.name)
-		game.Deck.ReturnCards(&game.PlayedCards)
+		game.StateMachine.Emit(Event{Type: DealerEvent, Player: game.DealerIndex})
+		game.Deck.ReturnCards(&game.DrawnCards)
 		return ResetDeckAndShuffle
 	}
 
@@ -178,7 +199,7 @@ func NewResetDeckAndShuffleState() *ResetDeckAndShuffleState {
 
 func (state *ResetDeckAndShuffleState) DoState(game *Game) StateName {
 	// reset deck
-	game.Deck.Shuffle()
+	game.shuffleDeck()
 
 	return DealCards
 }
@@ -203,26 +224,12 @@ func (state *DealCardsState) DoState(game *Game) StateName {
 	player := game.Players[playerIndex]
 
 	isFirstDeal := len(dealer.hand) == 0
+	position := (playerIndex-dealerIndex+len(game.Players)-1)%len(game.Players) + 1
 
-	if isFirstDeal {
-		// deal 2 cards to the player if they are the 1st or 3rd player
-		if playerIndex == (dealerIndex+1)%4 || playerIndex == (dealerIndex+3)%4 {
-			player.GiveCards(game.Deck.DrawCards(2))
-			game.Log("%s was dealt 2 cards", player.name)
-		} else {
-			player.GiveCards(game.Deck.DrawCards(3))
-			game.Log("%s was dealt 3 cards", player.name)
-		}
-	} else {
-		// deal 3 cards to the player if they are the 1st or 3rd player
-		if playerIndex == (dealerIndex+1)%4 || playerIndex == (dealerIndex+3)%4 {
-			player.GiveCards(game.Deck.DrawCards(3))
-			game.Log("%s was dealt 3 cards", player.name)
-		} else {
-			player.GiveCards(game.Deck.DrawCards(2))
-			game.Log("%s was dealt 2 cards", player.name)
-		}
-	}
+	cards := game.Deck.DrawCards(dealCount(position, isFirstDeal))
+	player.GiveCards(cards)
+	game.Log("%s was dealt %d cards", player.name, len(cards))
+	game.StateMachine.Emit(Event{Type: DealEvent, Player: playerIndex, Cards: cardValues(cards)})
 
 	// move onto next player
 	game.NextPlayer()
@@ -234,6 +241,17 @@ func (state *DealCardsState) DoState(game *Game) StateName {
 	return DealCards
 }
 
+// dealCount returns how many cards are dealt at once to the player position
+// seats left of the dealer. The first player gets 2 then 3, the next 3 then 2 and
+// so on around the table, so everyone ends up with 5 whether there are three or
+// four players.
+func dealCount(position int, firstDeal bool) int {
+	if (position%2 == 1) == firstDeal {
+		return 2
+	}
+	return 3
+}
+
 // ============================ RevealTopCardState ============================
 type RevealTopCardState struct {
 	NamedState
@@ -247,9 +265,11 @@ func NewRevealTopCardState() *RevealTopCardState {
 
 func (state *RevealTopCardState) DoState(game *Game) StateName {
 	game.TurnedCard = game.Deck.pop()
+	game.RevealedCard = *game.TurnedCard
 
 	// print out name of turned card
 	game.Log("%s was turned", game.TurnedCard.ToString())
+	game.StateMachine.Emit(Event{Type: TurnCardEvent, Player: game.DealerIndex, Cards: []Card{*game.TurnedCard}})
 	return TrumpSelectionOne
 }
 
@@ -260,7 +280,7 @@ type TrumpSelectionOneState struct {
 
 func NewTrumpSelectionOneState() *TrumpSelectionOneState {
 	gs := TrumpSelectionOneState{NamedState{Name: TrumpSelectionOne}}
-	gs.PossibleNextStates = []StateName{TrumpSelectionOne, DealerPickupTrump, TrumpSelectionTwo}
+	gs.PossibleNextStates = []StateName{TrumpSelectionOne, GoAlone, TrumpSelectionTwo}
 	return &gs
 }
 
@@ -269,16 +289,18 @@ func (state *TrumpSelectionOneState) DoState(game *Game) StateName {
 	player := game.Players[game.PlayerIndex]
 
 	// ask player if they want trump
-	pickedUp := GetTrumpSelectionOneInput(player, *game.TurnedCard)
+	pickedUp := player.controller.OrderUp(player, game)
 
 	// if picked up, we want to ask the dealer if they want the turned card
 	if pickedUp {
 		game.Log("%s ordered it up", player.name)
-		game.OrderedPlayerIndex = game.PlayerIndex
-		game.Trump = game.TurnedCard.suite
-		return DealerPickupTrump
+		game.StateMachine.Emit(Event{Type: OrderUpEvent, Player: game.PlayerIndex})
+		game.makeTrump(game.PlayerIndex, game.TurnedCard.suite)
+		return GoAlone
 	}
 
+	game.StateMachine.Emit(Event{Type: PassEvent, Player: game.PlayerIndex})
+
 	// if this player was the dealer, we will move on to Trump Selection Two
 	if game.PlayerIndex == game.DealerIndex {
 		game.NextPlayer()
@@ -297,19 +319,41 @@ type DealerPickupTrumpState struct {
 
 func NewDealerPickupTrumpState() *DealerPickupTrumpState {
 	gs := DealerPickupTrumpState{NamedState{Name: DealerPickupTrump}}
-	gs.PossibleNextStates = []StateName{StartRound}
+	gs.PossibleNextStates = []StateName{DealerPickupTrump, StartRound}
 	return &gs
 }
 
 func (state *DealerPickupTrumpState) DoState(game *Game) StateName {
 	dealer := game.Players[game.DealerIndex]
+
+	// the dealer doesn't pick up the card if their partner is going alone
+	if game.IsSittingOut(game.DealerIndex) {
+		game.Deck.ReturnCard(game.TurnedCard)
+		game.TurnedCard = nil
+		return StartRound
+	}
+
 	// give the dealer the turned card and let them exchange
-	dealer.GiveCard(game.TurnedCard)
-	burnCard := GetDealersBurnCard(dealer)
+	if !dealer.HasCard(game.TurnedCard) {
+		dealer.GiveCard(game.TurnedCard)
+	}
+	burnCard := dealer.controller.Discard(dealer, game)
+
+	// the dealer must discard a card from their hand. Ask again if they didn't
+	if !dealer.HasCard(burnCard) {
+		game.Log("Invalid card. You must discard a card from your hand.")
+		return DealerPickupTrump
+	}
+
+	if game.Rules.DealerMustPickUp && burnCard == game.TurnedCard {
+		game.Log("Invalid card. The dealer must keep the turned card.")
+		return DealerPickupTrump
+	}
 	dealer.ReturnCard(burnCard)
+	game.StateMachine.Emit(Event{Type: DiscardEvent, Player: game.DealerIndex, Cards: []Card{*burnCard}})
 	game.Deck.ReturnCard(burnCard)
 	game.TurnedCard = nil
-	game.PlayerIndex = (game.DealerIndex + 1) % 4 // first player is next to dealer
+	game.PlayerIndex = game.leftOf(game.DealerIndex) // first player is next to dealer
 	return StartRound
 }
 
@@ -320,30 +364,52 @@ type TrumpSelectionTwoState struct {
 
 func NewTrumpSelectionTwoState() *TrumpSelectionTwoState {
 	gs := TrumpSelectionTwoState{NamedState{Name: TrumpSelectionTwo}}
-	gs.PossibleNextStates = []StateName{TrumpSelectionTwo, StartRound, ScrewDealer}
+	gs.PossibleNextStates = []StateName{TrumpSelectionTwo, GoAlone, ScrewDealer, ResetDeckAndShuffle}
 	return &gs
 }
 
 func (state *TrumpSelectionTwoState) DoState(game *Game) StateName {
 	player := game.Players[game.PlayerIndex]
 
-	// if the player is the dealer, they must select a suite
-	if game.PlayerIndex == game.DealerIndex {
+	// if the player is the dealer and we're playing stick the dealer, they must select a suite
+	if game.PlayerIndex == game.DealerIndex && game.Rules.StickTheDealer {
 		game.Log("Dealer got screwed!")
 		return ScrewDealer
 	}
 
 	// otherwise, let the next player pick a suite if they want
-	selectedSuite := GetTrumpSelectionTwoInput(player, *game.TurnedCard)
+	selectedSuite := player.controller.PickSuite(player, game, false)
+
+	// the suite that was turned down can't be picked
+	if selectedSuite == game.TurnedCard.suite {
+		game.Log("Invalid suite. %s was turned down.", selectedSuite.ToString())
+		return TrumpSelectionTwo
+	}
 
 	// if the player selected a suite, set it as trump
 	if selectedSuite != NONE {
-		game.Trump = selectedSuite
-		game.PlayerIndex = (game.DealerIndex + 1) % 4 // first player is next to dealer
+		game.makeTrump(game.PlayerIndex, selectedSuite)
 		game.Deck.ReturnCard(game.TurnedCard)
 		game.TurnedCard = nil
 		game.Log("%s picked %s as trump", player.name, selectedSuite.ToString())
-		return StartRound
+		game.StateMachine.Emit(Event{Type: PickSuiteEvent, Player: game.PlayerIndex, Suite: selectedSuite})
+		return GoAlone
+	}
+
+	game.StateMachine.Emit(Event{Type: PassEvent, Player: game.PlayerIndex})
+
+	// if the dealer passed too, throw the hand in and pass the deal
+	if game.PlayerIndex == game.DealerIndex {
+		for _, p := range game.Players {
+			cards := p.ReturnCards()
+			game.Deck.ReturnCards(&cards)
+		}
+		game.Deck.ReturnCard(game.TurnedCard)
+		game.TurnedCard = nil
+		game.DealerIndex = game.leftOf(game.DealerIndex)
+		game.PlayerIndex = game.leftOf(game.DealerIndex)
+		game.Log("Everyone passed. The deal passes to %s.", game.Players[game.DealerIndex].name)
+		return ResetDeckAndShuffle
 	}
 
 	// move on to the next player
@@ -358,20 +424,59 @@ type ScrewDealerState struct {
 
 func NewScrewDealerState() *ScrewDealerState {
 	gs := ScrewDealerState{NamedState{Name: ScrewDealer}}
-	gs.PossibleNextStates = []StateName{StartRound}
+	gs.PossibleNextStates = []StateName{ScrewDealer, GoAlone}
 	return &gs
 }
 
 func (state *ScrewDealerState) DoState(game *Game) StateName {
 	player := game.Players[game.PlayerIndex]
 
-	selectedSuite := GetScrewTheDealerInput(player, *game.TurnedCard)
+	selectedSuite := player.controller.PickSuite(player, game, true)
+
+	// the dealer can't pass or pick the suite that was turned down
+	if selectedSuite == NONE || selectedSuite == game.TurnedCard.suite {
+		game.Log("Invalid suite. The dealer must pick a suite other than %s.", game.TurnedCard.suite.ToString())
+		return ScrewDealer
+	}
 
 	game.Log("Dealer %s picked %s as trump", player.name, selectedSuite.ToString())
+	game.StateMachine.Emit(Event{Type: PickSuiteEvent, Player: game.PlayerIndex, Suite: selectedSuite})
 
-	game.Trump = selectedSuite
+	game.makeTrump(game.PlayerIndex, selectedSuite)
 	game.Deck.ReturnCard(game.TurnedCard)
 	game.TurnedCard = nil
+	return GoAlone
+}
+
+// ============================ GoAloneState ============================
+type GoAloneState struct {
+	NamedState
+}
+
+func NewGoAloneState() *GoAloneState {
+	gs := GoAloneState{NamedState{Name: GoAlone}}
+	gs.PossibleNextStates = []StateName{DealerPickupTrump, StartRound}
+	return &gs
+}
+
+func (state *GoAloneState) DoState(game *Game) StateName {
+	player := game.Players[game.PlayerIndex]
+
+	// ask the player that made trump if they want to go alone. In cutthroat
+	// they're already on their own
+	if partner := game.partnerOf(game.PlayerIndex); partner != -1 {
+		alone := player.controller.GoAlone(player, game)
+		if alone {
+			game.AlonePlayerIndex = game.PlayerIndex
+			game.Log("%s is going alone. %s sits out this hand.", player.name, game.Players[partner].name)
+		}
+		game.StateMachine.Emit(Event{Type: LonerEvent, Player: game.PlayerIndex, Alone: alone})
+	}
+
+	// the turned card is still up if trump was ordered in the first round
+	if game.TurnedCard != nil {
+		return DealerPickupTrump
+	}
 	return StartRound
 }
 
@@ -388,7 +493,12 @@ func NewStartRoundState() *StartRoundState {
 }
 
 func (state *StartRoundState) DoState(game *Game) StateName {
-	game.PlayerIndex = (game.DealerIndex + 1) % 4
+	game.PlayerIndex = game.leftOf(game.DealerIndex)
+	if game.IsSittingOut(game.PlayerIndex) {
+		game.NextPlayer()
+	}
+	game.Trick = NewTrick(0)
+	game.HandPlays = make([]Play, 0)
 	return GetPlayerCard
 }
 
@@ -406,7 +516,7 @@ func NewGetPlayerCardState() *GetPlayerCardState {
 func (state *GetPlayerCardState) DoState(game *Game) StateName {
 	player := game.Players[game.PlayerIndex]
 
-	player.playedCard = GetCardInput(player)
+	player.playedCard = player.controller.PlayCard(player, game)
 	return CheckValidCard
 }
 
@@ -424,14 +534,8 @@ func NewCheckValidCardState() *CheckValidCardState {
 func (state *CheckValidCardState) DoState(game *Game) StateName {
 	player := game.Players[game.PlayerIndex]
 
-	var leadCard *Card = nil
-
-	if len(game.PlayedCards) > 0 {
-		leadCard = game.PlayedCards[0]
-	}
-
 	// if the card wasn't valid, go back to GetPlayerCardState
-	if !IsCardPlayable(player.playedCard, player.hand, game.Trump, leadCard) {
+	if !IsCardPlayable(player.playedCard, player.hand, game.Trump, game.Trick.LeadCard()) {
 		game.Log("Invalid card. You must follow suite.")
 		player.playedCard = nil
 		return GetPlayerCard
@@ -458,14 +562,15 @@ func (state *PlayCardState) DoState(game *Game) StateName {
 	// remove the card from the players hand
 	player.ReturnCard(player.playedCard)
 
-	// add the card to the played cards
+	// add the card to the trick
 	game.PlayCard(player.playedCard)
 
 	// print the card
 	game.Log("%s played %s", player.name, player.playedCard.ToString())
+	game.StateMachine.Emit(Event{Type: PlayCardEvent, Player: game.PlayerIndex, Cards: []Card{*player.playedCard}})
 
 	// if this is the last card, move on to GetTrickWinnerState
-	if len(game.PlayedCards) == 4 {
+	if game.Trick.Len() == game.ActivePlayerCount() {
 		return GetTrickWinner
 	}
 
@@ -487,36 +592,20 @@ func NewGetTrickWinnerState() *GetTrickWinnerState {
 
 func (state *GetTrickWinnerState) DoState(game *Game) StateName {
 
-	c1 := *game.PlayedCards[0]
-	c2 := *game.PlayedCards[1]
-	c3 := *game.PlayedCards[2]
-	c4 := *game.PlayedCards[3]
-
-	trump := game.Trump
-	lead := game.PlayedCards[0].suite
-
-	winningCard := GetWinningCard(c1, c2, c3, c4, trump, lead)
-	winningPlayer := game.Players[game.DealerIndex]
-
-	switch winningCard {
-	case c1:
-		winningPlayer = game.Players[(game.PlayerIndex+1)%4]
-	case c2:
-		winningPlayer = game.Players[(game.PlayerIndex+2)%4]
-	case c3:
-		winningPlayer = game.Players[(game.PlayerIndex+3)%4]
-	case c4:
-		winningPlayer = game.Players[game.PlayerIndex]
-	}
+	// find the highest card in the trick and who played it
+	winningPlay := game.Trick.Winner(game.Trump)
+	winningCard := winningPlay.Card
+	winningPlayer := game.Players[winningPlay.Seat]
 
 	// print the winner
 	game.Log("%s won the trick with a %s", winningPlayer.name, winningCard.ToString())
+	game.StateMachine.Emit(Event{Type: TrickWonEvent, Player: winningPlayer.index, Cards: []Card{winningCard}})
 
-	// give the winner the trick point
-	winningPlayer.tricksTaken += 1
+	// give the winner's team the trick
+	game.TeamOf(winningPlayer.index).Tricks += 1
 
 	// return the played cards to the deck
-	game.ReturnPlayedCards()
+	game.ReturnTrick()
 
 	// if this is the last trick, move on to GivePointsState
 	if len(winningPlayer.hand) == 0 {
@@ -542,57 +631,46 @@ func NewGivePointsState() *GivePointsState {
 }
 
 func (state *GivePointsState) DoState(game *Game) StateName {
-	player1 := game.Players[0]
-	player2 := game.Players[1]
-	player3 := game.Players[2]
-	player4 := game.Players[3]
-
-	teamOneTricks := player1.tricksTaken + player3.tricksTaken
-	teamTwoTricks := player2.tricksTaken + player4.tricksTaken
-
-	teamOneOrdered := game.OrderedPlayerIndex == 0 || game.OrderedPlayerIndex == 2
-
-	if teamOneOrdered {
-		if teamOneTricks == 5 {
-			// team one gets 2 points
-			player1.pointsEarned += 2
-			player3.pointsEarned += 2
-			game.Log("Team one won them all! They earned 2 points.")
-		} else if teamOneTricks >= 3 {
-			// team one gets 1 point
-			player1.pointsEarned += 1
-			player3.pointsEarned += 1
-			game.Log("Team one won %d tricks. They earned 1 point.", teamOneTricks)
-		} else {
-			// team two gets 2 points
-			player2.pointsEarned += 2
-			player4.pointsEarned += 2
-			game.Log("Team One got euchred! Team two earned 2 points.")
-		}
+	makers := game.MakerTeam()
+	wentAlone := game.AlonePlayerIndex != -1
+
+	points := make([]int, len(game.Teams))
+	if makers.Tricks == 5 && wentAlone {
+		// the makers get the loner points
+		points[makers.Index] = game.Rules.LonerPoints
+		game.Log("%s won them all alone! They earned %d points.", game.TeamName(makers), game.Rules.LonerPoints)
+	} else if makers.Tricks == 5 {
+		// the makers get the march points
+		points[makers.Index] = game.Rules.MarchPoints()
+		game.Log("%s won them all! They earned %d points.", game.TeamName(makers), game.Rules.MarchPoints())
+	} else if makers.Tricks >= 3 {
+		// the makers get 1 point
+		points[makers.Index] = 1
+		game.Log("%s won %d tricks. They earned 1 point.", game.TeamName(makers), makers.Tricks)
 	} else {
-		if teamTwoTricks == 5 {
-			// team two gets 2 points
-			player2.pointsEarned += 2
-			player4.pointsEarned += 2
-			game.Log("Team two won them all! They earned 2 points.")
-		} else if teamTwoTricks >= 3 {
-			// team two gets 1 point
-			player2.pointsEarned += 1
-			player4.pointsEarned += 1
-			game.Log("Team two won %d tricks. They earned 1 point.", teamTwoTricks)
-		} else {
-			// team one gets 2 points
-			player1.pointsEarned += 2
-			player3.pointsEarned += 2
-			game.Log("Team two got euchred! Team one earned 2 points.")
+		// the defenders get the euchre points
+		defenders := game.DefendingTeams()
+		for _, team := range defenders {
+			points[team.Index] = game.Rules.EuchrePoints
 		}
+		game.Log("%s got euchred! %s earned %d points.", game.TeamName(makers), game.teamNames(defenders), game.Rules.EuchrePoints)
 	}
 
-	// reset trick count
-	player1.tricksTaken = 0
-	player2.tricksTaken = 0
-	player3.tricksTaken = 0
-	player4.tricksTaken = 0
+	for _, team := range game.Teams {
+		if points[team.Index] > 0 {
+			team.Score += points[team.Index]
+			game.StateMachine.Emit(Event{Type: PointsEvent, Player: -1, Team: team.Index, Points: points[team.Index]})
+		}
+
+		// reset trick count
+		team.Tricks = 0
+	}
+
+	// return the hand of the player that sat out to the deck
+	for _, player := range game.Players {
+		cards := player.ReturnCards()
+		game.Deck.ReturnCards(&cards)
+	}
 
 	// check for winner
 	return CheckForWinner
@@ -610,29 +688,22 @@ func NewCheckForWinnerState() *CheckForWinnerState {
 }
 
 func (state *CheckForWinnerState) DoState(game *Game) StateName {
-	player1 := game.Players[0]
-	player2 := game.Players[1]
-
-	teamOnePoints := player1.pointsEarned
-	teamTwoPoints := player2.pointsEarned
-
-	game.Log("Team One Points: %d", teamOnePoints)
-	game.Log("Team Two Points: %d", teamTwoPoints)
+	for _, team := range game.Teams {
+		game.Log("%s Points: %d", game.TeamName(team), team.Score)
+	}
 
-	if teamOnePoints >= 4 {
-		game.Log("Team One wins!")
-		return EndGame
-	} else if teamTwoPoints >= 4 {
-		game.Log("Team Two wins!")
+	if winner := game.WinningTeam(); winner != nil {
+		game.Log("%s wins!", game.TeamName(winner))
 		return EndGame
 	}
 
 	// increment the dealer
-	game.DealerIndex = (game.DealerIndex + 1) % 4
-	game.PlayerIndex = (game.DealerIndex + 1) % 4
+	game.DealerIndex = game.leftOf(game.DealerIndex)
+	game.PlayerIndex = game.leftOf(game.DealerIndex)
 	game.Log("Dealer is now %s", game.Players[game.DealerIndex].name)
 
 	game.OrderedPlayerIndex = -1
+	game.AlonePlayerIndex = -1
 	return ResetDeckAndShuffle
 }
 
@@ -0,0 +1,122 @@
+package game
+
+import "strings"
+
+// Team is the players who score together. In the usual four handed game the
+// players sitting across from each other are a team. In cutthroat every player
+// is a team by themselves.
+type Team struct {
+	Index   int
+	Name    string // empty for a team of one, which goes by the player's name
+	Members []int  // the seats of the players on the team
+	Score   int    // points earned this game
+	Tricks  int    // tricks taken this hand
+}
+
+// newTeams makes the teams the rules call for: seats 0 and 2 against seats 1
+// and 3, or a team for every seat in cutthroat
+func newTeams(rules RuleSet) []*Team {
+	if rules.Cutthroat {
+		teams := make([]*Team, rules.PlayerCount())
+		for seat := range teams {
+			teams[seat] = &Team{Index: seat, Members: []int{seat}}
+		}
+		return teams
+	}
+	return []*Team{
+		{Index: 0, Name: "Team One", Members: []int{0, 2}},
+		{Index: 1, Name: "Team Two", Members: []int{1, 3}},
+	}
+}
+
+// HasMember returns true if the seat plays for the team
+func (t *Team) HasMember(seat int) bool {
+	for _, member := range t.Members {
+		if member == seat {
+			return true
+		}
+	}
+	return false
+}
+
+// TeamOf returns the team the seat plays for
+func (g *Game) TeamOf(seat int) *Team {
+	for _, team := range g.Teams {
+		if team.HasMember(seat) {
+			return team
+		}
+	}
+	return nil
+}
+
+// MakerTeam returns the team that made trump this hand, or nil if trump hasn't
+// been made
+func (g *Game) MakerTeam() *Team {
+	if g.OrderedPlayerIndex == -1 {
+		return nil
+	}
+	return g.TeamOf(g.OrderedPlayerIndex)
+}
+
+// DefendingTeams returns the teams playing against the makers this hand
+func (g *Game) DefendingTeams() []*Team {
+	makers := g.MakerTeam()
+	defenders := make([]*Team, 0, len(g.Teams))
+	for _, team := range g.Teams {
+		if team != makers {
+			defenders = append(defenders, team)
+		}
+	}
+	return defenders
+}
+
+// partnerOf returns the seat of the player's partner, or -1 if they don't have
+// one
+func (g *Game) partnerOf(seat int) int {
+	if team := g.TeamOf(seat); team != nil {
+		for _, member := range team.Members {
+			if member != seat {
+				return member
+			}
+		}
+	}
+	return -1
+}
+
+// WinningTeam returns the team that reached the target score, or nil if the game
+// isn't over. If more than one team got there on the same hand, which can happen
+// when the makers are euchred in cutthroat, the one with the most points wins.
+func (g *Game) WinningTeam() *Team {
+	var winner *Team = nil
+	for _, team := range g.Teams {
+		if team.Score >= g.Rules.TargetScore && (winner == nil || team.Score > winner.Score) {
+			winner = team
+		}
+	}
+	return winner
+}
+
+// makeTrump records that the player in seat made suite trump, so their team
+// are the makers for the hand
+func (g *Game) makeTrump(seat int, suite Suite) {
+	g.OrderedPlayerIndex = seat
+	g.Trump = suite
+}
+
+// TeamName returns the name of the team, which is the name of the player for a
+// team of one
+func (g *Game) TeamName(team *Team) string {
+	if team.Name == "" && len(team.Members) == 1 {
+		return g.Players[team.Members[0]].name
+	}
+	return team.Name
+}
+
+// teamNames joins the names of the teams for a log
+func (g *Game) teamNames(teams []*Team) string {
+	names := make([]string, len(teams))
+	for i, team := range teams {
+		names[i] = g.TeamName(team)
+	}
+	return strings.Join(names, " and ")
+}
@@ -0,0 +1,131 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeamOf(t *testing.T) {
+	game := NewGame(DefaultRuleSet())
+	assert.Equal(t, game.Teams[0], game.TeamOf(0))
+	assert.Equal(t, game.Teams[1], game.TeamOf(1))
+	assert.Equal(t, game.Teams[0], game.TeamOf(2))
+	assert.Equal(t, game.Teams[1], game.TeamOf(3))
+	assert.Nil(t, game.TeamOf(4))
+	assert.Nil(t, game.MakerTeam(), "expected no makers before trump is made")
+}
+
+func TestGivePointsToTeams(t *testing.T) {
+	tests := []struct {
+		name   string
+		maker  int
+		alone  bool
+		tricks int // tricks the makers took
+		scores [2]int
+	}{
+		{"makers take three", 0, false, 3, [2]int{1, 0}},
+		{"makers take four", 3, false, 4, [2]int{0, 1}},
+		{"makers march", 2, false, 5, [2]int{2, 0}},
+		{"loner marches", 1, true, 5, [2]int{0, 4}},
+		{"loner takes three", 1, true, 3, [2]int{0, 1}},
+		{"makers euchred", 0, false, 2, [2]int{0, 2}},
+		{"loner euchred", 3, true, 0, [2]int{2, 0}},
+	}
+	for _, test := range tests {
+		game := NewGame(DefaultRuleSet())
+		game.makeTrump(test.maker, HEART)
+		if test.alone {
+			game.AlonePlayerIndex = test.maker
+		}
+		makers := game.MakerTeam()
+		makers.Tricks = test.tricks
+		game.DefendingTeams()[0].Tricks = 5 - test.tricks
+
+		next := (&GivePointsState{}).DoState(&game)
+		assert.Equal(t, CheckForWinner, next, test.name)
+		for i, team := range game.Teams {
+			assert.Equal(t, test.scores[i], team.Score, test.name)
+			assert.Equal(t, 0, team.Tricks, "%s: expected the tricks to be reset", test.name)
+		}
+
+		for _, event := range game.StateMachine.Events {
+			assert.Equal(t, PointsEvent, event.Type, test.name)
+			assert.Equal(t, test.scores[event.Team], event.Points, test.name)
+		}
+	}
+}
+
+func TestMakersAreTheTeamThatMadeTrump(t *testing.T) {
+	game := NewGame(DefaultRuleSet())
+	setRuleBots(&game)
+	for hands := 0; hands < 20 && game.StateMachine.CurrentState.GetName() != EndGame; {
+		if game.StateMachine.CurrentState.GetName() == StartRound {
+			hands++
+			makers := game.MakerTeam()
+			assert.NotNil(t, makers)
+			assert.True(t, makers.HasMember(game.OrderedPlayerIndex))
+			assert.NotContains(t, game.DefendingTeams(), makers)
+		}
+		game.StateMachine.Step(&game)
+	}
+}
+
+func TestWinningTeam(t *testing.T) {
+	game := NewGame(DefaultRuleSet())
+	game.Teams[0].Score = 9
+	game.Teams[1].Score = 7
+	assert.Nil(t, game.WinningTeam())
+
+	game.Teams[1].Score = 10
+	assert.Equal(t, game.Teams[1], game.WinningTeam())
+
+	next := (&CheckForWinnerState{}).DoState(&game)
+	assert.Equal(t, EndGame, next)
+}
+
+func TestGivePointsInCutthroat(t *testing.T) {
+	tests := []struct {
+		name   string
+		maker  int
+		tricks int // tricks the maker took
+		scores [3]int
+	}{
+		{"maker takes three", 1, 3, [3]int{0, 1, 0}},
+		{"maker marches", 2, 5, [3]int{0, 0, 3}},
+		{"maker euchred", 0, 2, [3]int{0, 2, 2}},
+	}
+	for _, test := range tests {
+		rules := DefaultRuleSet()
+		rules.Cutthroat = true
+		game := NewGame(rules)
+		game.Players[0].name = "Alice"
+		game.makeTrump(test.maker, SPADE)
+		game.MakerTeam().Tricks = test.tricks
+
+		(&GivePointsState{}).DoState(&game)
+		for i, team := range game.Teams {
+			assert.Equal(t, test.scores[i], team.Score, test.name)
+		}
+	}
+}
+
+func TestCutthroatTeams(t *testing.T) {
+	rules := DefaultRuleSet()
+	rules.Cutthroat = true
+	game := NewGame(rules)
+	game.Players[1].name = "Bob"
+	for seat := range game.Players {
+		assert.Equal(t, []int{seat}, game.TeamOf(seat).Members)
+		assert.Equal(t, -1, game.partnerOf(seat))
+	}
+	assert.Equal(t, "Bob", game.TeamName(game.Teams[1]))
+
+	game.makeTrump(1, HEART)
+	assert.Equal(t, []*Team{game.Teams[0], game.Teams[2]}, game.DefendingTeams())
+
+	// both defenders can reach the target when the maker is euchred
+	game.Teams[0].Score = 10
+	game.Teams[2].Score = 11
+	assert.Equal(t, game.Teams[2], game.WinningTeam())
+}
@@ -0,0 +1,65 @@
+package game
+
+// Trick holds the cards played to a trick and who played each one, in the order
+// they were played. The zero Trick is the first trick of a hand with nothing
+// played yet.
+type Trick struct {
+	Number int     // the number of the trick in the hand, starting at 0
+	Plays  []Play  // the seat and card of every play so far
+	cards  []*Card // the cards that were played, returned to the deck once the trick is won
+}
+
+// NewTrick starts a trick with nothing played yet
+func NewTrick(number int) Trick {
+	return Trick{Number: number, Plays: make([]Play, 0)}
+}
+
+// Add records seat playing card
+func (t *Trick) Add(seat int, card *Card) {
+	t.Plays = append(t.Plays, Play{Seat: seat, Card: *card, Trick: t.Number})
+	t.cards = append(t.cards, card)
+}
+
+// Len returns the number of cards played to the trick
+func (t *Trick) Len() int {
+	return len(t.Plays)
+}
+
+// Leader returns the seat that led the trick, or -1 if nothing has been played
+func (t *Trick) Leader() int {
+	if len(t.Plays) == 0 {
+		return -1
+	}
+	return t.Plays[0].Seat
+}
+
+// LeadCard returns the card that was led, or nil if nothing has been played
+func (t *Trick) LeadCard() *Card {
+	if len(t.Plays) == 0 {
+		return nil
+	}
+	return &t.Plays[0].Card
+}
+
+// Winner returns the play that is winning the trick: the highest trump, or the
+// highest card of the effective suite that was led if no trump was played. The
+// trick must have at least one play.
+func (t *Trick) Winner(trump Suite) Play {
+	lead := EffectiveSuit(t.Plays[0].Card, trump)
+	winningPlay := t.Plays[0]
+	for _, play := range t.Plays[1:] {
+		if play.Card.compare(winningPlay.Card, trump, lead) > 0 {
+			winningPlay = play
+		}
+	}
+	return winningPlay
+}
+
+// Cards returns the cards played to the trick in order
+func (t *Trick) Cards() []Card {
+	cards := make([]Card, len(t.Plays))
+	for i, play := range t.Plays {
+		cards[i] = play.Card
+	}
+	return cards
+}
@@ -0,0 +1,65 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrickWinner(t *testing.T) {
+	tests := []struct {
+		name   string
+		trump  Suite
+		seats  []int
+		cards  string
+		winner int
+	}{
+		{"leader wins", HEART, []int{1, 2, 3, 0}, "AS KS QS 9S", 1},
+		{"last seat trumps in", HEART, []int{2, 3, 0, 1}, "AS KS QS 9H", 1},
+		{"same card value from different seats", CLUB, []int{3, 0, 1, 2}, "9D TD 9S 9H", 0},
+		{"loner trick of three", SPADE, []int{0, 1, 3}, "AD JC KD", 1},
+		{"left bauer led", DIAMOND, []int{2, 3, 0, 1}, "JH AD JD KH", 0},
+		{"only the lead", HEART, []int{3}, "9C", 3},
+	}
+	for _, test := range tests {
+		cards, err := ParseHand(test.cards)
+		assert.NoError(t, err)
+
+		trick := NewTrick(2)
+		for i, c := range cardPointers(cards) {
+			trick.Add(test.seats[i], c)
+		}
+		assert.Equal(t, test.seats[0], trick.Leader(), test.name)
+		assert.Equal(t, cards[0], *trick.LeadCard(), test.name)
+		assert.Equal(t, cards, trick.Cards(), test.name)
+
+		winner := trick.Winner(test.trump)
+		assert.Equal(t, test.winner, winner.Seat, test.name)
+		assert.Equal(t, 2, winner.Trick, test.name)
+	}
+}
+
+func TestEmptyTrick(t *testing.T) {
+	trick := NewTrick(0)
+	assert.Equal(t, -1, trick.Leader())
+	assert.Nil(t, trick.LeadCard())
+	assert.Equal(t, 0, trick.Len())
+}
+
+func TestGameReturnsTrickToDeck(t *testing.T) {
+	game := NewGame(DefaultRuleSet())
+	game.Deck = InitDeck(1)
+	hand := game.Deck.DrawCards(4)
+	for i, c := range hand {
+		game.PlayerIndex = (i + 1) % 4
+		game.PlayCard(c)
+	}
+	assert.Equal(t, 4, game.Trick.Len())
+	assert.Equal(t, 1, game.Trick.Leader())
+	assert.Len(t, game.HandPlays, 4)
+
+	game.ReturnTrick()
+	assert.Equal(t, 24, game.Deck.Length())
+	assert.Equal(t, 1, game.Trick.Number)
+	assert.Equal(t, 0, game.Trick.Len())
+}
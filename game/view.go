@@ -0,0 +1,110 @@
+package game
+
+// PlayerView is what one seat at the table can see of a game: their own hand
+// and the cards everybody can see, but only the number of cards in the other
+// hands
+type PlayerView struct {
+	Seat               int           `json:"seat"` // -1 for a spectator, who sees no hand
+	State              StateName     `json:"state"`
+	Names              []string      `json:"names"`
+	Hand               []Card        `json:"hand"`
+	HandSizes          []int         `json:"handSizes"`
+	DeckSize           int           `json:"deckSize"`
+	DealerIndex        int           `json:"dealerIndex"`
+	PlayerIndex        int           `json:"playerIndex"`
+	TurnedCard         *Card         `json:"turnedCard"`
+	PlayedCards        []Card        `json:"playedCards"` // face up on the table, including the draw for dealer
+	Trick              []Play        `json:"trick"`       // the plays in the current trick
+	Trump              Suite         `json:"trump"`
+	OrderedPlayerIndex int           `json:"orderedPlayerIndex"`
+	AlonePlayerIndex   int           `json:"alonePlayerIndex"`
+	TeamNames          []string      `json:"teamNames"`
+	Tricks             []int         `json:"tricks"` // tricks taken this hand by each team
+	Points             []int         `json:"points"`
+	TargetScore        int           `json:"targetScore"`
+	Bidding            []Event       `json:"bidding"`      // how trump was picked this hand
+	TrickHistory       []TrickRecord `json:"trickHistory"` // the tricks finished this hand
+}
+
+// TrickRecord is a finished trick: who played what, starting with the leader,
+// and who won it
+type TrickRecord struct {
+	Plays  []Play `json:"plays"`
+	Winner int    `json:"winner"`
+}
+
+// NewPlayerView returns what the player in seat can see of the game
+func NewPlayerView(game *Game, seat int) PlayerView {
+	view := PlayerView{}
+	view.Seat = seat
+	view.State = game.StateMachine.CurrentState.GetName()
+	view.Hand = make([]Card, 0)
+	view.Names = make([]string, len(game.Players))
+	view.HandSizes = make([]int, len(game.Players))
+	for i, player := range game.Players {
+		view.Names[i] = player.name
+		view.HandSizes[i] = len(player.hand)
+		if i == seat {
+			view.Hand = cardValues(player.hand)
+		}
+	}
+	view.DeckSize = len(game.Deck.cards)
+	view.DealerIndex = game.DealerIndex
+	view.PlayerIndex = game.PlayerIndex
+	if game.TurnedCard != nil {
+		turnedCard := *game.TurnedCard
+		view.TurnedCard = &turnedCard
+	}
+	view.PlayedCards = game.Trick.Cards()
+	if len(game.DrawnCards) > 0 {
+		view.PlayedCards = cardValues(game.DrawnCards)
+	}
+	view.Trick = append(make([]Play, 0), game.Trick.Plays...)
+	view.Trump = game.Trump
+	view.OrderedPlayerIndex = game.OrderedPlayerIndex
+	view.AlonePlayerIndex = game.AlonePlayerIndex
+	view.TeamNames = make([]string, len(game.Teams))
+	view.Tricks = make([]int, len(game.Teams))
+	view.Points = make([]int, len(game.Teams))
+	for _, team := range game.Teams {
+		view.TeamNames[team.Index] = game.TeamName(team)
+		view.Tricks[team.Index] = team.Tricks
+		view.Points[team.Index] = team.Score
+	}
+	view.TargetScore = game.Rules.TargetScore
+	view.Bidding, view.TrickHistory = handHistory(game.StateMachine.Events, seat)
+	return view
+}
+
+// handHistory returns the bidding and the finished tricks of the hand being
+// played, or the last one played if a new one hasn't been shuffled yet. Only the
+// dealer gets to see what they discarded.
+func handHistory(events []Event, seat int) ([]Event, []TrickRecord) {
+	start := 0
+	for i, event := range events {
+		if event.Type == ShuffleEvent {
+			start = i + 1
+		}
+	}
+
+	bidding := make([]Event, 0)
+	tricks := make([]TrickRecord, 0)
+	plays := make([]Play, 0)
+	for _, event := range events[start:] {
+		switch event.Type {
+		case OrderUpEvent, PassEvent, PickSuiteEvent, LonerEvent:
+			bidding = append(bidding, event)
+		case DiscardEvent:
+			if event.Player != seat {
+				event.Cards = nil
+			}
+			bidding = append(bidding, event)
+		case PlayCardEvent:
+			plays = append(plays, Play{Seat: event.Player, Card: event.Cards[0], Trick: len(tricks)})
+		case TrickWonEvent:
+			tricks = append(tricks, TrickRecord{Plays: plays, Winner: event.Player})
+			plays = make([]Play, 0)
+		}
+	}
+	return bidding, tricks
+}
@@ -0,0 +1,97 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlayerViewHidesOtherHands(t *testing.T) {
+	game := NewGame(DefaultRuleSet())
+	setRuleBots(&game)
+	for game.StateMachine.CurrentState.GetName() != TrumpSelectionOne {
+		game.StateMachine.Step(&game)
+	}
+
+	view := NewPlayerView(&game, 2)
+	assert.Equal(t, cardValues(game.Players[2].hand), view.Hand)
+	assert.Equal(t, []int{5, 5, 5, 5}, view.HandSizes)
+	assert.Equal(t, *game.TurnedCard, *view.TurnedCard)
+	assert.Equal(t, len(game.Deck.cards), view.DeckSize)
+	assert.Equal(t, game.DealerIndex, view.DealerIndex)
+
+	// changing the view doesn't change the game
+	view.Hand[0] = Card{}
+	assert.NotEqual(t, Card{}, *game.Players[2].hand[0])
+
+	spectator := NewPlayerView(&game, -1)
+	assert.Empty(t, spectator.Hand)
+}
+
+func TestPlayerViewShowsCurrentTrick(t *testing.T) {
+	game := NewGame(DefaultRuleSet())
+	setRuleBots(&game)
+	for len(game.HandPlays) < 2 || game.StateMachine.CurrentState.GetName() != GetPlayerCard {
+		game.StateMachine.Step(&game)
+	}
+
+	view := NewPlayerView(&game, 0)
+	assert.Equal(t, game.Trick.Plays, view.Trick)
+	assert.Equal(t, game.Trick.Cards(), view.PlayedCards)
+}
+
+func TestPlayerViewHandHistory(t *testing.T) {
+	game := NewGame(DefaultRuleSet())
+	setRuleBots(&game)
+	// play until two tricks of the first hand have been won
+	for len(handTricksWon(game.StateMachine.Events)) < 2 {
+		game.StateMachine.Step(&game)
+	}
+
+	view := NewPlayerView(&game, 0)
+	assert.Len(t, view.TrickHistory, 2)
+	for i, trick := range view.TrickHistory {
+		assert.Len(t, trick.Plays, game.ActivePlayerCount())
+		assert.Equal(t, handTricksWon(game.StateMachine.Events)[i].Player, trick.Winner)
+		for _, play := range trick.Plays {
+			assert.Equal(t, i, play.Trick)
+		}
+	}
+	assert.Equal(t, view.TrickHistory[0].Winner, view.TrickHistory[1].Plays[0].Seat, "expected the winner to lead the next trick")
+
+	assert.NotEmpty(t, view.Bidding)
+	for _, event := range view.Bidding {
+		if event.Type == DiscardEvent && event.Player != 0 {
+			assert.Empty(t, event.Cards, "expected only the dealer to see the discard")
+		}
+	}
+}
+
+func handTricksWon(events []Event) []Event {
+	won := make([]Event, 0)
+	for _, event := range events {
+		if event.Type == ShuffleEvent {
+			won = won[:0]
+		} else if event.Type == TrickWonEvent {
+			won = append(won, event)
+		}
+	}
+	return won
+}
+
+func TestHandHistoryHidesDiscard(t *testing.T) {
+	events := []Event{
+		{Type: ShuffleEvent, Player: -1},
+		{Type: OrderUpEvent, Player: 1},
+		{Type: LonerEvent, Player: 1},
+		{Type: DiscardEvent, Player: 3, Cards: []Card{{NINE, CLUB}}},
+	}
+
+	bidding, tricks := handHistory(events, 3)
+	assert.Equal(t, events[1:], bidding)
+	assert.Empty(t, tricks)
+
+	bidding, _ = handHistory(events, 0)
+	assert.Empty(t, bidding[2].Cards)
+	assert.NotEmpty(t, events[3].Cards, "expected the game's events not to change")
+}
@@ -1,9 +1,149 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
 	"github.com/mbaum0/euchrego/game"
 )
 
 func main() {
-	game.Run()
+	if len(os.Args) > 1 && os.Args[1] == "simulate" {
+		simulate(os.Args[2:])
+		return
+	}
+
+	bots := flag.String("bots", "", "comma separated list of players (1-4, or 1-3 in cutthroat) played by the computer")
+	saveFile := flag.String("save", "", "file the game is saved to on Ctrl-C or when \"save\" is typed. The game isn't saved if it's not set")
+	loadFile := flag.String("load", "", "saved game to resume")
+	eventLog := flag.String("events", "", "file to record the game's events in")
+	notation := flag.String("notation", "", "file to write the game to in the game notation when it ends")
+	serve := flag.String("serve", "", "host a game for remote players on this address, like :4000")
+	connect := flag.String("connect", "", "join a game hosted at this address")
+	name := flag.String("name", "Player", "your name when joining a remote game")
+	hotSeat := flag.Bool("hotseat", false, "blank the screen between players sharing this terminal")
+	seed := flag.Int64("seed", 0, "master seed the shuffle of every hand is derived from. Picked from the time if 0")
+	entropy := flag.Bool("entropy", true, "ask remote players for entropy to mix into every shuffle when hosting")
+	verify := flag.String("verify", "", "check a revealed shuffle, given as \"<commitment> <key> [<entropy>...]\", and print the deck")
+	strategy := flag.String("strategy", "rule", "strategy used by the computer players (rule or montecarlo)")
+	rules := game.DefaultRuleSet()
+	addRuleFlags(flag.CommandLine, &rules)
+	flag.Parse()
+
+	if *verify != "" {
+		deck, err := game.VerifyReveal(*verify)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Println("The key matches the commitment. The deck from the top was:")
+		for i := len(deck) - 1; i >= 0; i-- {
+			fmt.Printf("%s ", deck[i])
+		}
+		fmt.Println()
+		return
+	}
+
+	seats, err := parseSeats(*bots)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if *connect != "" {
+		if err := game.Connect(*connect, *name); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *serve != "" {
+		config := game.ServerConfig{}
+		config.Address = *serve
+		config.Bots = seats
+		config.BotStrategy = *strategy
+		config.Rules = rules
+		config.Seed = *seed
+		config.Entropy = *entropy
+		if err := game.Serve(config); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	config := game.RunConfig{}
+	config.Rules = rules
+	config.EventLog = *eventLog
+	config.SaveFile = *saveFile
+	config.LoadFile = *loadFile
+	config.Bots = seats
+	config.BotStrategy = *strategy
+	config.HotSeat = *hotSeat
+	config.NotationFile = *notation
+	config.Seed = *seed
+
+	game.Run(config)
+}
+
+// simulate plays games between bots and prints how each team did
+func simulate(args []string) {
+	flags := flag.NewFlagSet("simulate", flag.ExitOnError)
+	games := flags.Int("games", 1000, "number of games to play")
+	team1 := flags.String("team1", "rule", "strategy of players 1 and 3, or player 1 in cutthroat (rule or montecarlo)")
+	team2 := flags.String("team2", "rule", "strategy of players 2 and 4, or player 2 in cutthroat (rule or montecarlo)")
+	team3 := flags.String("team3", "rule", "strategy of player 3 in cutthroat (rule or montecarlo)")
+	seed := flags.Int64("seed", 0, "master seed every game's seed is derived from. Picked from the time if 0")
+	workers := flags.Int("workers", 0, "games played at once. Defaults to the number of CPUs")
+	rules := game.DefaultRuleSet()
+	addRuleFlags(flags, &rules)
+	flags.Parse(args)
+
+	config := game.SimulationConfig{}
+	config.Games = *games
+	config.Strategies = []string{*team1, *team2}
+	if rules.Cutthroat {
+		config.Strategies = append(config.Strategies, *team3)
+	}
+	config.Rules = rules
+	config.Seed = *seed
+	config.Workers = *workers
+
+	result, err := game.Simulate(config)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	result.Report(os.Stdout)
+}
+
+// addRuleFlags adds a flag for every rule that can be changed
+func addRuleFlags(flags *flag.FlagSet, rules *game.RuleSet) {
+	flags.IntVar(&rules.TargetScore, "target", rules.TargetScore, "points needed to win the game")
+	flags.BoolVar(&rules.StickTheDealer, "stick-the-dealer", rules.StickTheDealer, "make the dealer name trump when everyone passes instead of redealing")
+	flags.IntVar(&rules.LonerPoints, "loner-points", rules.LonerPoints, "points for taking all five tricks alone")
+	flags.IntVar(&rules.EuchrePoints, "euchre-points", rules.EuchrePoints, "points for euchring the makers")
+	flags.BoolVar(&rules.DealerMustPickUp, "dealer-must-pickup", rules.DealerMustPickUp, "make the dealer keep the turned card when it's ordered up")
+	flags.BoolVar(&rules.Cutthroat, "cutthroat", rules.Cutthroat, "play three handed, each player for themselves")
+}
+
+// parseSeats converts a list of player numbers like "2,4" into seat indexes
+func parseSeats(list string) ([]int, error) {
+	seats := make([]int, 0)
+	if list == "" {
+		return seats, nil
+	}
+
+	for _, field := range strings.Split(list, ",") {
+		player, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || player < 1 || player > 4 {
+			return nil, fmt.Errorf("invalid player %q, expected a number from 1 to 4", field)
+		}
+		seats = append(seats, player-1)
+	}
+	return seats, nil
 }